@@ -21,7 +21,7 @@ var (
 )
 
 func addKnownTypes(scheme *runtime.Scheme) error {
-	scheme.AddKnownTypes(GroupVersion, &SyncObject{}, &SyncObjectList{})
+	scheme.AddKnownTypes(GroupVersion, &SyncObject{}, &SyncObjectList{}, &NamespacedSyncObject{}, &NamespacedSyncObjectList{})
 	metav1.AddToGroupVersion(scheme, GroupVersion)
 	return nil
 }
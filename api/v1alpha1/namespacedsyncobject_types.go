@@ -0,0 +1,41 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.status.references[0].reference.kind`
+//+kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.status.references[0].reference.name`
+//+kubebuilder:printcolumn:name="References",type=integer,JSONPath=`.status.referenceCount`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+//+kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=`.status.desiredReplicas`
+//+kubebuilder:printcolumn:name="Synced",type=integer,JSONPath=`.status.syncedReplicas`
+//+kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedReplicas`
+//+kubebuilder:printcolumn:name="Last-Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NamespacedSyncObject is a SyncObject tenants can create themselves. Its
+// references have to point into its own namespace. It reads and writes as
+// the ServiceAccount "sync-operator" of its namespace, or the one in
+// spec.serviceAccountRef, and only replicates into the namespaces that
+// ServiceAccount is allowed to write the referenced kinds to.
+type NamespacedSyncObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SyncObjectSpec   `json:"spec,omitempty"`
+	Status SyncObjectStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NamespacedSyncObjectList contains a list of NamespacedSyncObject
+type NamespacedSyncObjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespacedSyncObject `json:"items"`
+}
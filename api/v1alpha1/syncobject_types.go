@@ -1,7 +1,10 @@
 package v1alpha1
 
 import (
+	"slices"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -9,20 +12,134 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // SyncObjectSpec defines the desired state of SyncObject
+// +kubebuilder:validation:XValidation:rule="has(self.reference) != has(self.references)",message="exactly one of reference and references must be set"
 // +kubebuilder:validation:XValidation:rule="!has(self.targetNamespaces) || !has(self.ignoreNamespaces) || !self.targetNamespaces.exists(n, n in self.ignoreNamespaces)",message="a namespace cannot be in both targetNamespaces and ignoreNamespaces"
+// +kubebuilder:validation:XValidation:rule="!has(self.ignoreNamespaces) || !('*' in self.ignoreNamespaces)",message="ignoreNamespaces cannot contain '*', it would exclude every namespace"
 type SyncObjectSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	Reference Reference `json:"reference"`
-	// If no target namespaces are defined, all namespaces will be used.
+	// Reference is the source to replicate. Use references instead to
+	// replicate several sources that belong together.
+	// +optional
+	Reference *Reference `json:"reference,omitempty"`
+	// References are several sources replicated together, sharing the
+	// target namespaces and lifecycle of this SyncObject. Removing an entry
+	// removes the replicas of that entry only.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	// +optional
+	References []Reference `json:"references,omitempty"`
+	// If neither target namespaces nor a target namespace selector are
+	// defined, all namespaces will be used.
+	//
+	// Besides plain names, entries can be globs like "ci-*", or regular
+	// expressions wrapped in slashes like "/tenant-[a-z]+-prod/", which have
+	// to match the whole name. Both are matched against the namespaces that
+	// exist, including ones created later.
 	// +kubebuilder:validation:MaxItems=1000
 	TargetNamespaces []string `json:"targetNamespaces,omitempty"`
-	// Explicitly skip replication to the specified namespaces.
+	// TargetNamespaceSelector selects target namespaces by their labels, in
+	// addition to any listed in targetNamespaces. Namespaces that start or
+	// stop matching, e.g. because they were relabelled, gain or lose their
+	// replicas straight away.
+	// +optional
+	TargetNamespaceSelector *metav1.LabelSelector `json:"targetNamespaceSelector,omitempty"`
+	// TargetName is the name of the replicas, instead of the name of their
+	// source. It can be a Go template, rendered for each replica with
+	// {{ .Name }} as the name of the source and {{ .Namespace.Name }} as
+	// the target namespace, e.g. "shared-{{ .Name }}".
+	//
+	// No two sources can end up with the same name in a namespace, so with
+	// several sources of a kind it has to include {{ .Name }}.
+	// +optional
+	TargetName string `json:"targetName,omitempty"`
+	// Explicitly skip replication to the specified namespaces. Entries can
+	// be globs or regular expressions, the same as in targetNamespaces.
 	// +kubebuilder:validation:MaxItems=1000
 	IgnoreNamespaces []string `json:"ignoreNamespaces,omitempty"`
+	// IncludeFields limits the replicas to these fields of the source, e.g.
+	// ".spec.rules" to replicate nothing else of an Ingress' spec. The
+	// apiVersion, kind and metadata are always kept. Paths are separated
+	// by dots, "[*]" stands for every item of a list, and a key containing
+	// dots is written as ['example.com/key'].
+	// +kubebuilder:validation:MaxItems=100
+	// +optional
+	IncludeFields []string `json:"includeFields,omitempty"`
+	// ExcludeFields removes these fields from the replicas, e.g.
+	// ".spec.replicas" or ".metadata.annotations". Paths are written as in
+	// includeFields, and excluded after those are included. The apiVersion,
+	// kind, name and namespace cannot be excluded.
+	// +kubebuilder:validation:MaxItems=100
+	// +optional
+	ExcludeFields []string `json:"excludeFields,omitempty"`
+	// Keys limits which keys of data, binaryData and stringData are
+	// replicated, e.g. only the ca.crt of a Secret that also holds a
+	// private key. The others never leave the source namespace.
+	// +optional
+	Keys *KeySelection `json:"keys,omitempty"`
+	// RenderTemplates renders the strings in each replica as Go templates,
+	// with {{ .Name }} as the name of the source and {{ .Namespace.Name }},
+	// {{ .Namespace.Labels }} and {{ .Namespace.Annotations }} describing
+	// the target namespace, e.g. "{{ .Namespace.Labels.region }}". The
+	// name, namespace and kind of the replica are never rendered, and
+	// neither are base64 encoded values like the data of a Secret.
+	// +optional
+	RenderTemplates bool `json:"renderTemplates,omitempty"`
+	// Patches modify the replicas before they are written, for example to
+	// give each namespace its own endpoint. They are applied in order. A
+	// replica whose patches fail to apply is not written, and the failure
+	// is reported for its namespace in status.references.
+	// +kubebuilder:validation:MaxItems=100
+	// +optional
+	Patches []Patch `json:"patches,omitempty"`
+	// CopyStatus writes the status of the source to its replicas, through
+	// their status subresource, after the rest is written. It is only for
+	// kinds that have one, and the status is copied as is, without
+	// templates or patches. By default replicas have no status of their
+	// own, apart from what a controller in the target namespace gives them.
+	// +optional
+	CopyStatus bool `json:"copyStatus,omitempty"`
+	// FieldConflicts decides what happens when another field manager, e.g.
+	// kubectl or a policy engine, has set a field of a replica that the
+	// source sets differently. Force, the default, takes the field over so
+	// the replica matches its source. Report leaves the replica alone and
+	// fails the sync for its namespace. Fields the source doesn't set are
+	// never touched either way.
+	// +kubebuilder:validation:Enum=Force;Report
+	// +kubebuilder:default=Force
+	// +optional
+	FieldConflicts FieldConflictPolicy `json:"fieldConflicts,omitempty"`
+	// UpdateStrategy is how a replica is changed when its source changes.
+	// Update, the default, changes it in place, which fails for immutable
+	// fields, like the data of an immutable ConfigMap or the pod template
+	// of a Job. Recreate deletes it and creates it anew on every change.
+	// RecreateOnImmutableError only does that when changing it in place
+	// failed because of an immutable field.
+	// +kubebuilder:validation:Enum=Update;Recreate;RecreateOnImmutableError
+	// +kubebuilder:default=Update
+	// +optional
+	UpdateStrategy UpdateStrategy `json:"updateStrategy,omitempty"`
+	// ConflictPolicy decides what happens to an object that isn't a replica
+	// but is in the way of one, having its name in a target namespace.
+	// Skip, the default, leaves it alone and reports it in
+	// status.references. Adopt turns it into a replica, keeping what it has
+	// on top of its source, and it is deleted along with the other replicas.
+	// Overwrite deletes it and creates the replica in its place.
+	// An object that is a replica of another SyncObject is always skipped.
+	// +kubebuilder:validation:Enum=Skip;Adopt;Overwrite
+	// +kubebuilder:default=Skip
+	// +optional
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty"`
 	// Don't add a finalizer which would clean up the replicas when this SyncObject gets deleted.
 	DisableFinalizer bool `json:"disableFinalizer,omitempty"`
+	// ServiceAccountRef is the ServiceAccount to act as when reading the
+	// sources and writing their replicas, instead of the operator itself,
+	// or for a NamespacedSyncObject instead of the ServiceAccount
+	// "sync-operator" of its namespace. Anything its RBAC doesn't allow
+	// fails with the Forbidden reason.
+	// +optional
+	ServiceAccountRef *ServiceAccountReference `json:"serviceAccountRef,omitempty"`
 	// ResyncInterval is how often the reference resource is re-checked and
 	// re-applied even without a detected change. Changes to the reference
 	// resource itself are synced immediately via a watch; this interval only
@@ -38,6 +155,10 @@ type SyncObjectSpec struct {
 	ResyncInterval metav1.Duration `json:"resyncInterval,omitempty"`
 }
 
+// Reference points at the source objects to replicate: either a single
+// object by name, or every object of the kind in the namespace matching a
+// label selector.
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.selector)",message="exactly one of name and selector must be set"
 type Reference struct {
 	// Group of the referenced resource, empty for the core group.
 	Group string `json:"group"`
@@ -46,11 +167,133 @@ type Reference struct {
 	// +kubebuilder:validation:MinLength=1
 	Kind string `json:"kind"`
 	// +kubebuilder:validation:MinLength=1
-	Name string `json:"name"`
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Selector replicates every object of the kind in the namespace whose
+	// labels match, instead of a single one by name. Objects that start
+	// matching get replicas, and the replicas of objects that stop matching
+	// are removed. Replicas are never selected as a source.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 	// +kubebuilder:validation:MinLength=1
 	Namespace string `json:"namespace"`
 }
 
+// PatchType is the format of a Patch.
+// +kubebuilder:validation:Enum=JSON;Merge;StrategicMerge
+type PatchType string
+
+const (
+	// JSONPatchType is an RFC 6902 JSON patch, a list of operations.
+	JSONPatchType PatchType = "JSON"
+	// MergePatchType is an RFC 7386 JSON merge patch.
+	MergePatchType PatchType = "Merge"
+	// StrategicMergePatchType is a Kubernetes strategic merge patch. It only
+	// works for the kinds built into Kubernetes, not for custom resources.
+	StrategicMergePatchType PatchType = "StrategicMerge"
+)
+
+// FieldConflictPolicy is what to do about fields of a replica that another
+// field manager owns.
+type FieldConflictPolicy string
+
+const (
+	// ForceFieldConflicts takes the fields over.
+	ForceFieldConflicts FieldConflictPolicy = "Force"
+	// ReportFieldConflicts fails the sync of the replica instead.
+	ReportFieldConflicts FieldConflictPolicy = "Report"
+)
+
+// UpdateStrategy is how a replica is changed when its source changes.
+type UpdateStrategy string
+
+const (
+	// UpdateUpdateStrategy changes replicas in place.
+	UpdateUpdateStrategy UpdateStrategy = "Update"
+	// RecreateUpdateStrategy deletes replicas whose source changed and
+	// creates them anew.
+	RecreateUpdateStrategy UpdateStrategy = "Recreate"
+	// RecreateOnImmutableErrorUpdateStrategy changes replicas in place, and
+	// recreates those where that failed because of an immutable field.
+	RecreateOnImmutableErrorUpdateStrategy UpdateStrategy = "RecreateOnImmutableError"
+)
+
+// ConflictPolicy is what to do about an object in the way of a replica.
+type ConflictPolicy string
+
+const (
+	// SkipConflictPolicy leaves the object alone.
+	SkipConflictPolicy ConflictPolicy = "Skip"
+	// AdoptConflictPolicy turns the object into a replica.
+	AdoptConflictPolicy ConflictPolicy = "Adopt"
+	// OverwriteConflictPolicy replaces the object with a replica.
+	OverwriteConflictPolicy ConflictPolicy = "Overwrite"
+)
+
+// Patch modifies some or all of the replicas.
+type Patch struct {
+	// Type is the format of the patch.
+	// +kubebuilder:default=Merge
+	// +optional
+	Type PatchType `json:"type,omitempty"`
+	// Patch is the patch itself: a list of operations for a JSON patch, an
+	// object otherwise. It must not change the name, namespace or kind of
+	// the replica, nor the marks the operator puts on it.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Patch runtime.RawExtension `json:"patch"`
+	// Namespaces limits the patch to the replicas in these target
+	// namespaces. Entries can be globs or regular expressions, the same as
+	// in targetNamespaces. Empty means every target namespace.
+	// +kubebuilder:validation:MaxItems=1000
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Kind limits the patch to the replicas of this kind, for a SyncObject
+	// with references of several kinds.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Name limits the patch to the replicas of the source with this name.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// KeySelection picks the keys of data, binaryData and stringData to
+// replicate, such as those of a ConfigMap or Secret.
+type KeySelection struct {
+	// Include lists the keys to replicate. Entries can be globs like
+	// "*.crt". Empty means every key.
+	// +optional
+	Include []string `json:"include,omitempty"`
+	// Exclude lists keys not to replicate, even when included. Entries can
+	// be globs too.
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+	// Rename gives keys a different name in the replicas, from the key in
+	// the source to the key in the replicas.
+	// +optional
+	Rename map[string]string `json:"rename,omitempty"`
+}
+
+// ServiceAccountReference points at a ServiceAccount.
+type ServiceAccountReference struct {
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the ServiceAccount. Required for a SyncObject; a
+	// NamespacedSyncObject can only use a ServiceAccount of its own
+	// namespace, which is also the default.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// AllReferences returns the references to replicate, whether given as
+// spec.reference or as spec.references.
+func (s SyncObjectSpec) AllReferences() []Reference {
+	if s.Reference != nil {
+		return append([]Reference{*s.Reference}, s.References...)
+	}
+	return slices.Clone(s.References)
+}
+
 // GroupVersionKind returns the GroupVersionKind the reference points at.
 func (r Reference) GroupVersionKind() schema.GroupVersionKind {
 	return schema.GroupVersionKind{Group: r.Group, Version: r.Version, Kind: r.Kind}
@@ -61,13 +304,44 @@ type SyncObjectStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	// AppliedReference is the reference whose replicas were last created.
-	// It's what lets a change to spec.reference clean up the replicas of
-	// the previous reference, which are named after it and would otherwise
-	// be orphaned.
+	// AppliedReference is the reference whose replicas were last created,
+	// as recorded before references could be a list. It's only read, to
+	// clean up after a SyncObject written by an older version, and replaced
+	// by AppliedReferences on the next sync.
 	// +optional
 	AppliedReference *Reference `json:"appliedReference,omitempty"`
 
+	// AppliedReferences are the references whose replicas may exist. It's
+	// what lets a change to, or the removal of, a reference clean up the
+	// replicas of the previous one, which are named after it and would
+	// otherwise be orphaned.
+	// +optional
+	AppliedReferences []Reference `json:"appliedReferences,omitempty"`
+
+	// References holds the outcome of the last sync for each reference.
+	// +optional
+	References []ReferenceStatus `json:"references,omitempty"`
+	// ReferenceCount is how many references there are, for the printer
+	// columns, which show the first of them only.
+	// +optional
+	ReferenceCount int32 `json:"referenceCount,omitempty"`
+
+	// DesiredReplicas is how many replicas all references should have.
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas"`
+	// SyncedReplicas is how many of them match their source.
+	// +optional
+	SyncedReplicas int32 `json:"syncedReplicas"`
+	// FailedReplicas is how many of them failed to sync. The rest have an
+	// object in their way.
+	// +optional
+	FailedReplicas int32 `json:"failedReplicas"`
+
+	// LastSyncTime is when a replica was last written, or first found to
+	// already match its source.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
 	// ObservedGeneration is the metadata.generation this status was last
 	// reconciled from. When it trails metadata.generation, the most recent
 	// change to the spec has not been acted on yet.
@@ -82,6 +356,121 @@ type SyncObjectStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// ReferenceStatus is the outcome of the last sync of a single reference.
+type ReferenceStatus struct {
+	Reference Reference `json:"reference"`
+	// Synced is whether every replica of the reference was created or
+	// updated successfully. Conflicts that were skipped don't count.
+	Synced bool `json:"synced"`
+	// Message says why the reference failed to sync.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// PatchFailures are the replicas whose patches failed to apply, and
+	// which were therefore not written. At most 100 are listed across all
+	// references.
+	// +kubebuilder:validation:MaxItems=100
+	// +optional
+	PatchFailures []PatchFailure `json:"patchFailures,omitempty"`
+	// Recreations are the replicas most recently deleted and created anew
+	// because of the updateStrategy, newest first. Unlike the rest, they
+	// are kept across syncs.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	Recreations []Recreation `json:"recreations,omitempty"`
+	// Conflicts are the target namespaces where an object that isn't a
+	// replica is in the way of one, and was skipped. At most 100 are listed
+	// across all references.
+	// +kubebuilder:validation:MaxItems=100
+	// +optional
+	Conflicts []Conflict `json:"conflicts,omitempty"`
+	// Replicas is the outcome of the last sync for each replica, those that
+	// didn't sync first. To keep the SyncObject within the size limits of
+	// an object, at most 500 replicas are listed across all references.
+	// +kubebuilder:validation:MaxItems=500
+	// +optional
+	Replicas []ReplicaStatus `json:"replicas,omitempty"`
+	// OmittedReplicas is how many replicas were left out of replicas.
+	// +optional
+	OmittedReplicas int32 `json:"omittedReplicas,omitempty"`
+	// DesiredReplicas is how many replicas the reference should have.
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas"`
+	// SyncedReplicas is how many of them match their source.
+	// +optional
+	SyncedReplicas int32 `json:"syncedReplicas"`
+	// FailedReplicas is how many of them failed to sync. The rest have an
+	// object in their way.
+	// +optional
+	FailedReplicas int32 `json:"failedReplicas"`
+}
+
+// ReplicaState is the outcome of the last sync of a replica.
+// +kubebuilder:validation:Enum=Synced;Failed;Conflict
+type ReplicaState string
+
+const (
+	// SyncedReplicaState is a replica that matches its source.
+	SyncedReplicaState ReplicaState = "Synced"
+	// FailedReplicaState is a replica that couldn't be written.
+	FailedReplicaState ReplicaState = "Failed"
+	// ConflictReplicaState is a replica with an object in its way.
+	ConflictReplicaState ReplicaState = "Conflict"
+)
+
+// ReplicaStatus is the outcome of the last sync of a single replica.
+type ReplicaStatus struct {
+	// Namespace the replica is in.
+	Namespace string `json:"namespace"`
+	// Name of the replica.
+	Name string `json:"name"`
+	// State is whether the replica synced.
+	State ReplicaState `json:"state"`
+	// LastSyncTime is when the replica was last written, or first found to
+	// already match its source.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// SourceResourceVersion is the resourceVersion of the source the
+	// replica was last synced from.
+	// +optional
+	SourceResourceVersion string `json:"sourceResourceVersion,omitempty"`
+	// Message says why the replica didn't sync.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// Conflict is an object in the way of a replica.
+type Conflict struct {
+	// Namespace the object is in.
+	Namespace string `json:"namespace"`
+	// Name of the object, which is also that of the replica.
+	Name string `json:"name"`
+	// Message says what the object is.
+	Message string `json:"message"`
+}
+
+// Recreation is a replica that was deleted and created anew.
+type Recreation struct {
+	// Namespace the replica is in.
+	Namespace string `json:"namespace"`
+	// Name of the replica.
+	Name string `json:"name"`
+	// Reason is Recreate when the updateStrategy recreates on every change,
+	// or ImmutableField when changing it in place failed.
+	Reason string `json:"reason"`
+	// Time it was recreated.
+	Time metav1.Time `json:"time"`
+}
+
+// PatchFailure is a replica whose patches failed to apply.
+type PatchFailure struct {
+	// Namespace the replica is in.
+	Namespace string `json:"namespace"`
+	// Name of the replica.
+	Name string `json:"name"`
+	// Message says why the patches failed.
+	Message string `json:"message"`
+}
+
 // ConditionReady is set on a SyncObject to report whether its last sync
 // succeeded.
 const ConditionReady = "Ready"
@@ -89,11 +478,16 @@ const ConditionReady = "Ready"
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
-//+kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.spec.reference.kind`
-//+kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.spec.reference.name`
-//+kubebuilder:printcolumn:name="Source-Namespace",type=string,JSONPath=`.spec.reference.namespace`,priority=1
+//+kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.status.references[0].reference.kind`
+//+kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.status.references[0].reference.name`
+//+kubebuilder:printcolumn:name="References",type=integer,JSONPath=`.status.referenceCount`
+//+kubebuilder:printcolumn:name="Source-Namespace",type=string,JSONPath=`.status.references[0].reference.namespace`,priority=1
 //+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 //+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+//+kubebuilder:printcolumn:name="Desired",type=integer,JSONPath=`.status.desiredReplicas`
+//+kubebuilder:printcolumn:name="Synced",type=integer,JSONPath=`.status.syncedReplicas`
+//+kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedReplicas`
+//+kubebuilder:printcolumn:name="Last-Sync",type=date,JSONPath=`.status.lastSyncTime`
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // SyncObject is the Schema for the syncobjects API
@@ -101,6 +495,7 @@ type SyncObject struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
+	// +kubebuilder:validation:XValidation:rule="!has(self.serviceAccountRef) || (has(self.serviceAccountRef.__namespace__) && self.serviceAccountRef.__namespace__ != '')",message="serviceAccountRef.namespace is required for a SyncObject"
 	Spec   SyncObjectSpec   `json:"spec,omitempty"`
 	Status SyncObjectStatus `json:"status,omitempty"`
 }
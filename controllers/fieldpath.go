@@ -0,0 +1,278 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// fieldPathSegment is a single step of a field path: a key of an object,
+// or every item of a list.
+type fieldPathSegment struct {
+	key      string
+	allItems bool
+}
+
+// parseFieldPath parses a path like ".spec.ports[*].nodePort", or
+// ".metadata.annotations['example.com/key']" for a key containing dots. The
+// leading dot is optional.
+func parseFieldPath(fieldPath string) ([]fieldPathSegment, error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("invalid field path %q: %s", fieldPath, reason)
+	}
+
+	rest := strings.TrimPrefix(fieldPath, ".")
+	if rest == "" {
+		return nil, invalid("it is empty")
+	}
+
+	var segments []fieldPathSegment
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "[*]"):
+			segments = append(segments, fieldPathSegment{allItems: true})
+			rest = rest[len("[*]"):]
+		case strings.HasPrefix(rest, "['"):
+			end := strings.Index(rest, "']")
+			if end < 0 {
+				return nil, invalid("unterminated ['")
+			}
+			if end == len("['") {
+				return nil, invalid("empty key")
+			}
+			segments = append(segments, fieldPathSegment{key: rest[len("['"):end]})
+			rest = rest[end+len("']"):]
+		case strings.HasPrefix(rest, "["):
+			return nil, invalid("only [*] and ['key'] are supported in brackets")
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, invalid("empty key")
+			}
+			segments = append(segments, fieldPathSegment{key: rest[:end]})
+			rest = rest[end:]
+		}
+
+		if strings.HasPrefix(rest, ".") {
+			rest = rest[1:]
+			if rest == "" {
+				return nil, invalid("it ends in a dot")
+			}
+		} else if rest != "" && !strings.HasPrefix(rest, "[") {
+			return nil, invalid("expected a dot or a bracket")
+		}
+	}
+	return segments, nil
+}
+
+// alwaysKeptFields are what includeFields never drops: without them the
+// replica isn't an object at all.
+var alwaysKeptFields = []string{"apiVersion", "kind", "metadata"}
+
+// includeFields drops everything from the replica apart from the fields at
+// the given paths, and those in alwaysKeptFields.
+func includeFields(replica *unstructured.Unstructured, fieldPaths []string) error {
+	if len(fieldPaths) == 0 {
+		return nil
+	}
+
+	included := map[string]any{}
+	for _, field := range alwaysKeptFields {
+		if value, ok := replica.Object[field]; ok {
+			included[field] = value
+		}
+	}
+
+	for _, fieldPath := range fieldPaths {
+		segments, err := parseFieldPath(fieldPath)
+		if err != nil {
+			return err
+		}
+		if extracted, ok := extractField(replica.Object, segments); ok {
+			included = mergeFields(included, extracted).(map[string]any)
+		}
+	}
+
+	replica.Object = included
+	return nil
+}
+
+// extractField returns a copy of value pruned down to the field at the
+// path, and whether there was anything there.
+func extractField(value any, segments []fieldPathSegment) (any, bool) {
+	if len(segments) == 0 {
+		return runtime.DeepCopyJSONValue(value), true
+	}
+	segment, rest := segments[0], segments[1:]
+
+	if segment.allItems {
+		items, ok := value.([]any)
+		if !ok {
+			return nil, false
+		}
+		// Items without the field stay as empty objects, so that a second
+		// path into the same list still lines up with the first.
+		extracted := make([]any, len(items))
+		found := false
+		for i, item := range items {
+			if itemValue, ok := extractField(item, rest); ok {
+				extracted[i] = itemValue
+				found = true
+			} else {
+				extracted[i] = map[string]any{}
+			}
+		}
+		return extracted, found
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	field, ok := object[segment.key]
+	if !ok {
+		return nil, false
+	}
+	extracted, ok := extractField(field, rest)
+	if !ok {
+		return nil, false
+	}
+	return map[string]any{segment.key: extracted}, true
+}
+
+// mergeFields merges what two paths extracted from the same object.
+func mergeFields(dst, src any) any {
+	switch d := dst.(type) {
+	case map[string]any:
+		s, ok := src.(map[string]any)
+		if !ok {
+			return src
+		}
+		for key, value := range s {
+			if existing, ok := d[key]; ok {
+				d[key] = mergeFields(existing, value)
+			} else {
+				d[key] = value
+			}
+		}
+		return d
+	case []any:
+		s, ok := src.([]any)
+		if !ok || len(s) != len(d) {
+			return src
+		}
+		for i := range d {
+			d[i] = mergeFields(d[i], s[i])
+		}
+		return d
+	default:
+		return src
+	}
+}
+
+// excludeFields removes the fields at the given paths from the replica.
+// What identifies it can't be removed: without its apiVersion and kind it
+// isn't an object, and without its name and namespace it would be written
+// somewhere else.
+func excludeFields(replica *unstructured.Unstructured, fieldPaths []string) error {
+	for _, fieldPath := range fieldPaths {
+		segments, err := parseFieldPath(fieldPath)
+		if err != nil {
+			return err
+		}
+		if identifiesObject(segments) {
+			return fmt.Errorf("cannot exclude %q, it identifies the replica", fieldPath)
+		}
+		removeField(replica.Object, segments)
+	}
+	return nil
+}
+
+// identifiesObject reports whether the path is, or holds, the apiVersion,
+// kind, name or namespace of an object.
+func identifiesObject(segments []fieldPathSegment) bool {
+	switch segments[0].key {
+	case "apiVersion", "kind":
+		return true
+	case "metadata":
+		return len(segments) == 1 || segments[1].key == "name" || segments[1].key == "namespace"
+	}
+	return false
+}
+
+// removeField removes the field at the path from value, if it's there, and
+// returns what is left.
+func removeField(value any, segments []fieldPathSegment) any {
+	segment, rest := segments[0], segments[1:]
+
+	if segment.allItems {
+		items, ok := value.([]any)
+		if !ok {
+			return value
+		}
+		if len(rest) == 0 {
+			// every item, but the list itself stays
+			return []any{}
+		}
+		for i := range items {
+			items[i] = removeField(items[i], rest)
+		}
+		return items
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	if len(rest) == 0 {
+		delete(object, segment.key)
+		return object
+	}
+	if field, ok := object[segment.key]; ok {
+		object[segment.key] = removeField(field, rest)
+	}
+	return object
+}
+
+// containsFields reports whether current has every field of desired, with
+// the same value. Fields only current has, like those the API server
+// defaulted or another controller added, don't matter. An empty object or
+// list in desired is also satisfied by the field being absent, as the API
+// server may drop those.
+func containsFields(current, desired any) bool {
+	switch d := desired.(type) {
+	case map[string]any:
+		c, ok := current.(map[string]any)
+		if !ok {
+			return current == nil && len(d) == 0
+		}
+		for key, value := range d {
+			if !containsFields(c[key], value) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		c, ok := current.([]any)
+		if !ok {
+			return current == nil && len(d) == 0
+		}
+		if len(c) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !containsFields(c[i], d[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return equality.Semantic.DeepEqual(current, desired)
+	}
+}
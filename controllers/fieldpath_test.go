@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseFieldPath(t *testing.T) {
+	tests := []struct {
+		fieldPath string
+		want      []fieldPathSegment
+		wantErr   string
+	}{
+		{fieldPath: ".status", want: []fieldPathSegment{{key: "status"}}},
+		{fieldPath: "spec.clusterIP", want: []fieldPathSegment{{key: "spec"}, {key: "clusterIP"}}},
+		{fieldPath: ".spec.ports[*].nodePort", want: []fieldPathSegment{{key: "spec"}, {key: "ports"}, {allItems: true}, {key: "nodePort"}}},
+		{fieldPath: ".metadata.annotations['example.com/key']", want: []fieldPathSegment{{key: "metadata"}, {key: "annotations"}, {key: "example.com/key"}}},
+		{fieldPath: "", wantErr: "it is empty"},
+		{fieldPath: ".spec.", wantErr: "it ends in a dot"},
+		{fieldPath: ".spec..rules", wantErr: "empty key"},
+		{fieldPath: ".spec.ports[0]", wantErr: "only [*] and ['key']"},
+		{fieldPath: ".metadata.annotations['unterminated", wantErr: "unterminated"},
+		{fieldPath: ".spec.ports[*]x", wantErr: "expected a dot or a bracket"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fieldPath, func(t *testing.T) {
+			got, err := parseFieldPath(tt.fieldPath)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func newService() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata": map[string]any{
+			"name":        "web",
+			"namespace":   "origin-ns",
+			"annotations": map[string]any{"example.com/key": "value", "other": "value"},
+		},
+		"spec": map[string]any{
+			"clusterIP": "10.0.0.1",
+			"selector":  map[string]any{"app": "web"},
+			"ports": []any{
+				map[string]any{"name": "http", "port": int64(80), "nodePort": int64(30080)},
+				map[string]any{"name": "https", "port": int64(443), "nodePort": int64(30443)},
+			},
+		},
+		"status": map[string]any{"loadBalancer": map[string]any{}},
+	}}
+}
+
+func TestIncludeFields(t *testing.T) {
+	service := newService()
+	require.NoError(t, includeFields(service, []string{".spec.selector", ".spec.ports[*].name", ".spec.ports[*].port", ".spec.missing"}))
+
+	require.Equal(t, map[string]any{
+		"selector": map[string]any{"app": "web"},
+		"ports": []any{
+			map[string]any{"name": "http", "port": int64(80)},
+			map[string]any{"name": "https", "port": int64(443)},
+		},
+	}, service.Object["spec"])
+	require.NotContains(t, service.Object, "status")
+	require.Equal(t, "web", service.GetName(), "the metadata is always kept")
+	require.Equal(t, "Service", service.GetKind())
+
+	t.Run("nothing to include keeps everything", func(t *testing.T) {
+		service := newService()
+		require.NoError(t, includeFields(service, nil))
+		require.Equal(t, newService(), service)
+	})
+}
+
+func TestExcludeFields(t *testing.T) {
+	service := newService()
+	require.NoError(t, excludeFields(service, []string{".status", ".spec.clusterIP", ".spec.ports[*].nodePort", ".metadata.annotations['example.com/key']", ".spec.missing.deeper"}))
+
+	require.NotContains(t, service.Object, "status")
+	require.Equal(t, map[string]any{
+		"selector": map[string]any{"app": "web"},
+		"ports": []any{
+			map[string]any{"name": "http", "port": int64(80)},
+			map[string]any{"name": "https", "port": int64(443)},
+		},
+	}, service.Object["spec"])
+	require.Equal(t, map[string]string{"other": "value"}, service.GetAnnotations())
+
+	for _, fieldPath := range []string{".kind", ".apiVersion", ".metadata", ".metadata.name", ".metadata.namespace"} {
+		require.ErrorContains(t, excludeFields(newService(), []string{fieldPath}), "identifies the replica", fieldPath)
+	}
+}
+
+func TestContainsFields(t *testing.T) {
+	desired := map[string]any{
+		"spec": map[string]any{
+			"ports": []any{map[string]any{"port": int64(80)}},
+			"extra": map[string]any{},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		current any
+		want    bool
+	}{
+		{"identical", map[string]any{"spec": map[string]any{"ports": []any{map[string]any{"port": int64(80)}}, "extra": map[string]any{}}}, true},
+		{"defaulted fields on top", map[string]any{"spec": map[string]any{"ports": []any{map[string]any{"port": int64(80), "protocol": "TCP"}}, "type": "ClusterIP"}}, true},
+		{"empty object dropped", map[string]any{"spec": map[string]any{"ports": []any{map[string]any{"port": int64(80)}}}}, true},
+		{"changed value", map[string]any{"spec": map[string]any{"ports": []any{map[string]any{"port": int64(8080)}}}}, false},
+		{"missing field", map[string]any{"spec": map[string]any{"ports": []any{map[string]any{}}}}, false},
+		{"extra list item", map[string]any{"spec": map[string]any{"ports": []any{map[string]any{"port": int64(80)}, map[string]any{"port": int64(443)}}}}, false},
+		{"different type", map[string]any{"spec": "ports"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, containsFields(tt.current, desired))
+		})
+	}
+}
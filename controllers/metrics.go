@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	syncv1alpha1 "github.com/sj14/sync-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// The metrics of the operator, served by the metrics endpoint of the
+// manager next to those of controller-runtime. A SyncObject is identified
+// by syncObjectID, a kind is that of the replicas.
+var (
+	replicasDesired = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sync_operator_replicas_desired",
+		Help: "Number of replicas a SyncObject should have, per kind.",
+	}, []string{"syncobject", "kind"})
+	replicasSynced = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sync_operator_replicas_synced",
+		Help: "Number of replicas of a SyncObject that match their source, per kind.",
+	}, []string{"syncobject", "kind"})
+	replicasFailed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sync_operator_replicas_failed",
+		Help: "Number of replicas of a SyncObject that couldn't be written, per kind.",
+	}, []string{"syncobject", "kind"})
+	syncObjectReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sync_operator_syncobject_ready",
+		Help: "Whether the last sync of a SyncObject succeeded (1) or failed (0), as its Ready condition.",
+	}, []string{"syncobject"})
+
+	replicaWrites = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sync_operator_replica_writes_total",
+		Help: "Number of replicas written, per kind and operation: create, update, adopt, recreate or overwrite.",
+	}, []string{"kind", "operation"})
+	replicaDeletions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sync_operator_replica_deletions_total",
+		Help: "Number of replicas deleted, per kind.",
+	}, []string{"kind"})
+
+	propagationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "sync_operator_propagation_duration_seconds",
+		Help: "Time from a change of a source until every replica of it was written, per kind.",
+		// from half a second up to about half an hour
+		Buckets: prometheus.ExponentialBuckets(0.5, 2, 13),
+	}, []string{"kind"})
+
+	watchedKinds = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "sync_operator_watched_kinds",
+		Help: "Number of kinds watched for changes to sources and replicas.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		replicasDesired,
+		replicasSynced,
+		replicasFailed,
+		syncObjectReady,
+		replicaWrites,
+		replicaDeletions,
+		propagationDuration,
+		watchedKinds,
+	)
+}
+
+// reportReplicas sets the replica gauges and syncObjectReady of the
+// SyncObject from its status. Kinds it no longer replicates are dropped.
+func reportReplicas(syncObject syncv1alpha1.SyncObject, ready bool) {
+	id := prometheus.Labels{"syncobject": syncObjectID(syncObject)}
+	replicasDesired.DeletePartialMatch(id)
+	replicasSynced.DeletePartialMatch(id)
+	replicasFailed.DeletePartialMatch(id)
+
+	for _, ref := range syncObject.Status.References {
+		kind := ref.Reference.Kind
+		replicasDesired.WithLabelValues(id["syncobject"], kind).Add(float64(ref.DesiredReplicas))
+		replicasSynced.WithLabelValues(id["syncobject"], kind).Add(float64(ref.SyncedReplicas))
+		replicasFailed.WithLabelValues(id["syncobject"], kind).Add(float64(ref.FailedReplicas))
+	}
+
+	value := 0.0
+	if ready {
+		value = 1
+	}
+	syncObjectReady.With(id).Set(value)
+}
+
+// forgetSyncObject drops the metrics of a SyncObject that is gone.
+func forgetSyncObject(id string) {
+	labels := prometheus.Labels{"syncobject": id}
+	replicasDesired.DeletePartialMatch(labels)
+	replicasSynced.DeletePartialMatch(labels)
+	replicasFailed.DeletePartialMatch(labels)
+	syncObjectReady.DeletePartialMatch(labels)
+}
+
+// writeOperation names what replicate did to a replica that was current
+// before, for replicaWrites.
+func writeOperation(written replicaWrite, current *unstructured.Unstructured, syncObject syncv1alpha1.SyncObject) string {
+	switch {
+	case written == replicaOverwritten:
+		return "overwrite"
+	case written == replicaRecreated:
+		return "recreate"
+	case current == nil:
+		return "create"
+	case !isOwnReplica(current, syncObject):
+		return "adopt"
+	default:
+		return "update"
+	}
+}
+
+// maxTrackedSources is how many sources propagationTracker remembers
+// before it starts over.
+const maxTrackedSources = 10000
+
+// propagationTracker observes propagationDuration once for every change of
+// a source, when all of its replicas have caught up with it. The zero value
+// is ready to use.
+type propagationTracker struct {
+	mu sync.Mutex
+	// observed is the resourceVersion of each source whose propagation was
+	// last observed.
+	observed map[types.UID]string
+}
+
+// synced is called when every replica of the source matches it. wrote is
+// whether any of them had to be written for that. A replica that was
+// written because someone edited it, rather than because the source
+// changed, is not a propagation, so each resourceVersion is observed only
+// once.
+//
+// A source already in sync when first seen is not observed, as it is
+// unknown how long ago its replicas caught up. Neither is a change that
+// needed no writes, such as one to the status of the source.
+func (t *propagationTracker) synced(source *unstructured.Unstructured, wrote bool, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.observed[source.GetUID()] == source.GetResourceVersion() {
+		return
+	}
+	if t.observed == nil || len(t.observed) >= maxTrackedSources {
+		// forgetting a source only costs an observation
+		t.observed = map[types.UID]string{}
+	}
+	t.observed[source.GetUID()] = source.GetResourceVersion()
+
+	if wrote {
+		propagationDuration.WithLabelValues(source.GetKind()).Observe(now.Sub(lastChanged(source)).Seconds())
+	}
+}
+
+// lastChanged returns when the object was last written, as far as its
+// metadata tells: the latest time of its managed fields, or its creation.
+func lastChanged(obj *unstructured.Unstructured) time.Time {
+	changed := obj.GetCreationTimestamp().Time
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Time != nil && entry.Time.After(changed) {
+			changed = entry.Time.Time
+		}
+	}
+	return changed
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	syncv1alpha1 "github.com/sj14/sync-operator/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReportReplicas(t *testing.T) {
+	syncObject := syncv1alpha1.SyncObject{ObjectMeta: metav1.ObjectMeta{Name: "metrics-test"}}
+	syncObject.Status.References = []syncv1alpha1.ReferenceStatus{
+		{Reference: syncv1alpha1.Reference{Kind: "ConfigMap", Name: "a"}, DesiredReplicas: 3, SyncedReplicas: 3},
+		{Reference: syncv1alpha1.Reference{Kind: "ConfigMap", Name: "b"}, DesiredReplicas: 3, SyncedReplicas: 1, FailedReplicas: 2},
+		{Reference: syncv1alpha1.Reference{Kind: "Secret", Name: "c"}, DesiredReplicas: 1, SyncedReplicas: 1},
+	}
+
+	reportReplicas(syncObject, false)
+	require.Equal(t, 6.0, testutil.ToFloat64(replicasDesired.WithLabelValues("metrics-test", "ConfigMap")), "summed across references")
+	require.Equal(t, 4.0, testutil.ToFloat64(replicasSynced.WithLabelValues("metrics-test", "ConfigMap")))
+	require.Equal(t, 2.0, testutil.ToFloat64(replicasFailed.WithLabelValues("metrics-test", "ConfigMap")))
+	require.Equal(t, 1.0, testutil.ToFloat64(replicasDesired.WithLabelValues("metrics-test", "Secret")))
+	require.Equal(t, 0.0, testutil.ToFloat64(syncObjectReady.WithLabelValues("metrics-test")))
+
+	syncObject.Status.References = syncObject.Status.References[2:]
+	reportReplicas(syncObject, true)
+	require.Equal(t, 1.0, testutil.ToFloat64(syncObjectReady.WithLabelValues("metrics-test")))
+	require.False(t, replicasDesired.DeleteLabelValues("metrics-test", "ConfigMap"), "a kind no longer replicated is dropped")
+	require.Equal(t, 1.0, testutil.ToFloat64(replicasDesired.WithLabelValues("metrics-test", "Secret")))
+
+	forgetSyncObject("metrics-test")
+	require.False(t, replicasDesired.DeleteLabelValues("metrics-test", "Secret"))
+	require.False(t, syncObjectReady.DeleteLabelValues("metrics-test"))
+}
+
+func TestWriteOperation(t *testing.T) {
+	own := &unstructured.Unstructured{}
+	markAsReplica(own, testSyncObject, client.ObjectKey{Namespace: testRef.Namespace, Name: testRef.Name})
+	foreign := &unstructured.Unstructured{}
+
+	tests := []struct {
+		written replicaWrite
+		current *unstructured.Unstructured
+		want    string
+	}{
+		{replicaApplied, nil, "create"},
+		{replicaApplied, own, "update"},
+		{replicaApplied, foreign, "adopt"},
+		{replicaRecreated, own, "recreate"},
+		{replicaOverwritten, foreign, "overwrite"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			require.Equal(t, tt.want, writeOperation(tt.written, tt.current, testSyncObject))
+		})
+	}
+}
+
+// observations returns how often propagationDuration was observed for the
+// kind, and the sum of the observations.
+func observations(t *testing.T, kind string) (uint64, float64) {
+	t.Helper()
+	var metric dto.Metric
+	require.NoError(t, propagationDuration.WithLabelValues(kind).(prometheus.Histogram).Write(&metric))
+	return metric.GetHistogram().GetSampleCount(), metric.GetHistogram().GetSampleSum()
+}
+
+func TestPropagationTracker(t *testing.T) {
+	now := time.Now()
+	source := &unstructured.Unstructured{}
+	source.SetKind("PropagationTest")
+	source.SetUID("source-uid")
+	source.SetResourceVersion("1")
+	source.SetCreationTimestamp(metav1.NewTime(now.Add(-time.Hour)))
+
+	var tracker propagationTracker
+	tracker.synced(source, false, now)
+	count, _ := observations(t, "PropagationTest")
+	require.Zero(t, count, "a source in sync when first seen is not observed")
+
+	source.SetResourceVersion("2")
+	changed := metav1.NewTime(now.Add(-30 * time.Second))
+	source.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl", Time: &changed}})
+	tracker.synced(source, true, now)
+	count, sum := observations(t, "PropagationTest")
+	require.Equal(t, uint64(1), count)
+	require.InDelta(t, 30, sum, 1, "measured from the last change of the source")
+
+	tracker.synced(source, true, now.Add(time.Hour))
+	count, _ = observations(t, "PropagationTest")
+	require.Equal(t, uint64(1), count, "writing a replica that was edited is not a propagation")
+
+	source.SetResourceVersion("3")
+	tracker.synced(source, false, now)
+	count, _ = observations(t, "PropagationTest")
+	require.Equal(t, uint64(1), count, "a change that needed no writes is not observed")
+}
+
+func TestLastChanged(t *testing.T) {
+	created := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	applied := metav1.NewTime(created.Add(time.Hour))
+	updated := metav1.NewTime(created.Add(time.Minute))
+
+	obj := &unstructured.Unstructured{}
+	obj.SetCreationTimestamp(created)
+	require.True(t, created.Time.Equal(lastChanged(obj)))
+
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl", Time: &applied}, {Manager: "helm", Time: &updated}, {Manager: "other"}})
+	require.True(t, applied.Time.Equal(lastChanged(obj)))
+}
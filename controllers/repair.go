@@ -0,0 +1,397 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	syncv1alpha1 "github.com/sj14/sync-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// namespaceChange is why a namespace of a SyncObject needs looking at.
+type namespaceChange uint8
+
+const (
+	// replicaChanged is a replica in the namespace that was edited or
+	// deleted.
+	replicaChanged namespaceChange = 1 << iota
+	// namespaceChanged is the namespace itself, created or relabelled.
+	namespaceChanged
+)
+
+// pendingChanges is what changed for a SyncObject since its last
+// reconcile.
+type pendingChanges struct {
+	// sources is whether a source changed, which concerns every replica
+	sources    bool
+	namespaces map[string]namespaceChange
+}
+
+// changeTracker collects what the watches saw changing, for each
+// SyncObject, so its next reconcile can repair just that instead of
+// syncing every replica. It also remembers when each was last synced in
+// full. The zero value is ready to use.
+type changeTracker struct {
+	mu       sync.Mutex
+	pending  map[types.NamespacedName]*pendingChanges
+	lastSync map[types.NamespacedName]time.Time
+}
+
+func (t *changeTracker) changes(key types.NamespacedName) *pendingChanges {
+	if t.pending == nil {
+		t.pending = map[types.NamespacedName]*pendingChanges{}
+	}
+	changes, ok := t.pending[key]
+	if !ok {
+		changes = &pendingChanges{namespaces: map[string]namespaceChange{}}
+		t.pending[key] = changes
+	}
+	return changes
+}
+
+// sourceChanged records that a source of the SyncObject changed.
+func (t *changeTracker) sourceChanged(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.changes(key).sources = true
+}
+
+// changed records that something in the namespace changed.
+func (t *changeTracker) changed(key types.NamespacedName, namespace string, change namespaceChange) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.changes(key).namespaces[namespace] |= change
+}
+
+// take returns what changed for the SyncObject, and forgets it.
+func (t *changeTracker) take(key types.NamespacedName) pendingChanges {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	changes, ok := t.pending[key]
+	if !ok {
+		return pendingChanges{}
+	}
+	delete(t.pending, key)
+	return *changes
+}
+
+// synced records that a full sync of the SyncObject started at the time.
+func (t *changeTracker) synced(key types.NamespacedName, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.lastSync == nil {
+		t.lastSync = map[types.NamespacedName]time.Time{}
+	}
+	t.lastSync[key] = at
+}
+
+// lastSynced returns when the last full sync of the SyncObject started, if
+// there was one.
+func (t *changeTracker) lastSynced(key types.NamespacedName) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	at, ok := t.lastSync[key]
+	return at, ok
+}
+
+// forget drops everything about a SyncObject that is gone.
+func (t *changeTracker) forget(key types.NamespacedName) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.pending, key)
+	delete(t.lastSync, key)
+}
+
+// untilResync returns how long until the SyncObject is due for its next
+// full sync, and whether it can do without one until then: it was synced in
+// full before, no source changed, and the last sync left it Ready for its
+// current spec.
+func (r *SyncObjectReconciler) untilResync(syncObject syncv1alpha1.SyncObject, changes pendingChanges) (time.Duration, bool) {
+	lastSync, ok := r.changes.lastSynced(client.ObjectKeyFromObject(&syncObject))
+	if !ok || changes.sources {
+		return 0, false
+	}
+	remaining := resyncInterval(syncObject) - time.Since(lastSync)
+	if remaining <= 0 {
+		return 0, false
+	}
+
+	ready := meta.FindStatusCondition(syncObject.Status.Conditions, syncv1alpha1.ConditionReady)
+	if ready == nil || ready.Status != metav1.ConditionTrue || ready.ObservedGeneration != syncObject.Generation ||
+		syncObject.Status.ObservedGeneration != syncObject.Generation {
+		return 0, false
+	}
+	if len(staleReferences(syncObject)) > 0 {
+		return 0, false
+	}
+	return remaining, true
+}
+
+// errRepairNotEnough is repair finding something only a full sync handles.
+var errRepairNotEnough = errors.New("repairing is not enough")
+
+// repair brings the replicas in the given namespaces in line with their
+// sources, and leaves every other replica alone. It returns the status of
+// the references, with those replicas updated.
+//
+// It is what a replica edited by hand, or a namespace created, costs:
+// writing the replicas in that namespace, rather than going over every one
+// in every namespace, and listing them all to find leftovers. Anything else
+// returns errRepairNotEnough, for a full sync to take care of: a replica
+// that fails or is in a conflict, a namespace that may have replicas to
+// remove, and everything that would take more than a write to record.
+func (r *SyncObjectReconciler) repair(ctx context.Context, syncObject syncv1alpha1.SyncObject, namespaces map[string]namespaceChange) ([]syncv1alpha1.ReferenceStatus, error) {
+	refs := syncObject.Spec.AllReferences()
+	results := make([]syncv1alpha1.ReferenceStatus, len(syncObject.Status.References))
+	for i := range syncObject.Status.References {
+		syncObject.Status.References[i].DeepCopyInto(&results[i])
+		// recorded before, and kept by updateStatus
+		results[i].Recreations = nil
+	}
+	if len(results) != len(refs) {
+		return nil, errRepairNotEnough
+	}
+	for i := range refs {
+		if !equality.Semantic.DeepEqual(results[i].Reference, refs[i]) {
+			return nil, errRepairNotEnough
+		}
+	}
+
+	c, err := r.clientFor(syncObject)
+	if err != nil {
+		return nil, err
+	}
+	name, err := newReplicaNamer(syncObject.Spec.TargetName)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns, err := checkNamespacePatterns(syncObject.Spec)
+	if err != nil {
+		return nil, errRepairNotEnough
+	}
+	patches := compilePatches(syncObject.Spec.Patches)
+
+	targeted := map[string]bool{}
+	claimed := map[replicaKey]client.ObjectKey{}
+	for i, ref := range refs {
+		if err := r.checkNamespaced(ref); err != nil {
+			return nil, errRepairNotEnough
+		}
+		if syncObject.Namespace != "" {
+			if err := r.checkTenantSource(ctx, syncObject, ref); err != nil {
+				return nil, errRepairNotEnough
+			}
+		}
+		targetNamespaces, err := r.getTargetNamespaces(ctx, syncObject, patterns, ref)
+		if err != nil {
+			return nil, errRepairNotEnough
+		}
+		targetNamespaces = slices.DeleteFunc(targetNamespaces, func(namespace string) bool {
+			_, changed := namespaces[namespace]
+			return !changed
+		})
+		if syncObject.Namespace != "" && len(targetNamespaces) > 0 {
+			targetNamespaces, err = r.tenantTargetNamespaces(ctx, syncObject, ref, targetNamespaces)
+			if err != nil {
+				return nil, errRepairNotEnough
+			}
+		}
+		if len(targetNamespaces) == 0 {
+			continue
+		}
+		originals, err := getOriginals(ctx, c, ref)
+		if err != nil {
+			return nil, errRepairNotEnough
+		}
+
+		// What the counts have of the replicas left out of the status,
+		// which stays as it is.
+		desired, synced, failed := countReplicas(results[i].Replicas)
+		desired, synced, failed = results[i].DesiredReplicas-desired, results[i].SyncedReplicas-synced, results[i].FailedReplicas-failed
+
+		var added []syncv1alpha1.ReplicaStatus
+		for _, namespace := range targetNamespaces {
+			targeted[namespace] = true
+			for _, original := range originals {
+				source := client.ObjectKeyFromObject(original)
+				replicaName, err := name(source.Name, namespace)
+				if err != nil {
+					return nil, errRepairNotEnough
+				}
+				key := replicaKey{GroupKind: original.GroupVersionKind().GroupKind(), ObjectKey: client.ObjectKey{Namespace: namespace, Name: replicaName}}
+				if other, ok := claimed[key]; ok && other != source {
+					return nil, errRepairNotEnough
+				}
+				claimed[key] = source
+
+				// Whether the replica is new to the status: it is when the
+				// namespace only just came into scope, rather than when a
+				// replica was deleted by hand.
+				existed := true
+				switch namespaces[namespace] {
+				case replicaChanged:
+				case namespaceChanged:
+					probe := &unstructured.Unstructured{}
+					probe.SetGroupVersionKind(original.GroupVersionKind())
+					probe.SetNamespace(namespace)
+					probe.SetName(replicaName)
+					current, err := r.currentReplica(ctx, probe)
+					if err != nil {
+						return nil, err
+					}
+					existed = current != nil
+				default:
+					// no telling which
+					return nil, errRepairNotEnough
+				}
+
+				written, err := r.replicate(ctx, c, syncObject, patches, original, namespace, replicaName)
+				if err != nil || (written != replicaUnchanged && written != replicaApplied) {
+					return nil, errRepairNotEnough
+				}
+
+				replicaStatus := syncv1alpha1.ReplicaStatus{
+					Namespace:             namespace,
+					Name:                  replicaName,
+					State:                 syncv1alpha1.SyncedReplicaState,
+					SourceResourceVersion: original.GetResourceVersion(),
+				}
+				if written == replicaApplied {
+					now := metav1.Now()
+					replicaStatus.LastSyncTime = &now
+				}
+				listed := slices.IndexFunc(results[i].Replicas, func(replica syncv1alpha1.ReplicaStatus) bool {
+					return replica.Namespace == namespace && replica.Name == replicaName
+				})
+				switch {
+				case listed >= 0:
+					if replicaStatus.LastSyncTime == nil {
+						replicaStatus.LastSyncTime = results[i].Replicas[listed].LastSyncTime
+					}
+					results[i].Replicas[listed] = replicaStatus
+				case !existed:
+					added = append(added, replicaStatus)
+				}
+			}
+		}
+		// A replica listed as failed before may be synced now.
+		replicas := append(results[i].Replicas, added...)
+		listedDesired, listedSynced, listedFailed := countReplicas(replicas)
+		results[i].DesiredReplicas = desired + listedDesired
+		results[i].SyncedReplicas = synced + listedSynced
+		results[i].FailedReplicas = failed + listedFailed
+		if len(added) > 0 {
+			var omitted int32
+			results[i].Replicas, omitted = limitReplicaStatuses(replicas, maxReplicaStatuses/len(refs))
+			results[i].OmittedReplicas += omitted
+		}
+	}
+
+	// A namespace that isn't a target of any reference may have replicas
+	// to remove. When all that changed there is a replica, which is gone
+	// now, that is one deleted along with its namespace, or by a sync, and
+	// only the status still lists it.
+	for namespace, change := range namespaces {
+		if targeted[namespace] {
+			continue
+		}
+		if change != replicaChanged {
+			return nil, errRepairNotEnough
+		}
+		left, err := r.hasReplicasIn(ctx, syncObject, namespace)
+		if err != nil || left {
+			return nil, errRepairNotEnough
+		}
+		for i := range results {
+			if !forgetNamespace(&results[i], namespace) {
+				return nil, errRepairNotEnough
+			}
+		}
+	}
+
+	return results, nil
+}
+
+// forgetNamespace removes what the status of a reference says about the
+// namespace, whose replicas are all gone, from its counts as well. It
+// reports false when some replicas were left out of the status, any of
+// which may have been in there.
+func forgetNamespace(status *syncv1alpha1.ReferenceStatus, namespace string) bool {
+	if status.OmittedReplicas > 0 {
+		return false
+	}
+	status.Replicas = slices.DeleteFunc(status.Replicas, func(replica syncv1alpha1.ReplicaStatus) bool {
+		if replica.Namespace != namespace {
+			return false
+		}
+		status.DesiredReplicas--
+		switch replica.State {
+		case syncv1alpha1.SyncedReplicaState:
+			status.SyncedReplicas--
+		case syncv1alpha1.FailedReplicaState:
+			status.FailedReplicas--
+		}
+		return true
+	})
+	status.Conflicts = slices.DeleteFunc(status.Conflicts, func(conflict syncv1alpha1.Conflict) bool {
+		return conflict.Namespace == namespace
+	})
+	status.PatchFailures = slices.DeleteFunc(status.PatchFailures, func(failure syncv1alpha1.PatchFailure) bool {
+		return failure.Namespace == namespace
+	})
+	return true
+}
+
+// countReplicas returns how many replicas there are, and how many of them
+// are synced and failed.
+func countReplicas(replicas []syncv1alpha1.ReplicaStatus) (desired, synced, failed int32) {
+	for _, replica := range replicas {
+		desired++
+		switch replica.State {
+		case syncv1alpha1.SyncedReplicaState:
+			synced++
+		case syncv1alpha1.FailedReplicaState:
+			failed++
+		}
+	}
+	return desired, synced, failed
+}
+
+// hasReplicasIn reports whether the cache holds any replica of the
+// SyncObject in the namespace. The replicas of a kind that isn't watched,
+// such as that of a stale reference, are asked for instead.
+func (r *SyncObjectReconciler) hasReplicasIn(ctx context.Context, syncObject syncv1alpha1.SyncObject, namespace string) (bool, error) {
+	if r.cache == nil {
+		return true, nil
+	}
+	for _, ref := range referencesToCleanUp(syncObject) {
+		listGVK := ref.GroupVersionKind()
+		listGVK.Kind += "List"
+		var replicas unstructured.UnstructuredList
+		replicas.SetGroupVersionKind(listGVK)
+		err := r.cache.List(ctx, &replicas, client.InNamespace(namespace))
+		var notCached *cache.ErrResourceNotCached
+		if errors.As(err, &notCached) {
+			err = r.Client.List(ctx, &replicas, client.InNamespace(namespace), client.MatchingLabels{managedByLabel: managedByValue})
+		}
+		if err != nil {
+			return false, err
+		}
+		for _, replica := range replicas.Items {
+			if isReplicaOf(&replica, syncObject, ref) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
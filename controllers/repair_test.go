@@ -0,0 +1,311 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	syncv1alpha1 "github.com/sj14/sync-operator/api/v1alpha1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestChangeTracker(t *testing.T) {
+	key := client.ObjectKey{Name: "owner"}
+	var tracker changeTracker
+
+	require.Equal(t, pendingChanges{}, tracker.take(key), "nothing changed yet")
+
+	tracker.changed(key, "a", replicaChanged)
+	tracker.changed(key, "a", namespaceChanged)
+	tracker.changed(key, "b", namespaceChanged)
+	changes := tracker.take(key)
+	require.False(t, changes.sources)
+	require.Equal(t, map[string]namespaceChange{"a": replicaChanged | namespaceChanged, "b": namespaceChanged}, changes.namespaces)
+	require.Equal(t, pendingChanges{}, tracker.take(key), "taking forgets the changes")
+
+	tracker.sourceChanged(key)
+	require.True(t, tracker.take(key).sources)
+
+	_, ok := tracker.lastSynced(key)
+	require.False(t, ok)
+	now := time.Now()
+	tracker.synced(key, now)
+	at, ok := tracker.lastSynced(key)
+	require.True(t, ok)
+	require.Equal(t, now, at)
+
+	tracker.changed(key, "a", replicaChanged)
+	tracker.forget(key)
+	_, ok = tracker.lastSynced(key)
+	require.False(t, ok)
+	require.Equal(t, pendingChanges{}, tracker.take(key))
+}
+
+// readySyncObject returns testSyncObject as a sync that succeeded for its
+// current spec leaves it.
+func readySyncObject() syncv1alpha1.SyncObject {
+	syncObject := *testSyncObject.DeepCopy()
+	syncObject.Generation = 2
+	syncObject.Status.ObservedGeneration = 2
+	meta.SetStatusCondition(&syncObject.Status.Conditions, metav1.Condition{
+		Type:               syncv1alpha1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Synced",
+		ObservedGeneration: 2,
+	})
+	syncObject.Status.References = []syncv1alpha1.ReferenceStatus{{Reference: testRef}}
+	return syncObject
+}
+
+func TestUntilResync(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*syncv1alpha1.SyncObject)
+		changes  pendingChanges
+		lastSync time.Duration
+		want     bool
+	}{
+		{name: "ready", lastSync: time.Minute, want: true},
+		{name: "never synced in full"},
+		{name: "source changed", lastSync: time.Minute, changes: pendingChanges{sources: true}},
+		{name: "resync due", lastSync: 2 * time.Hour},
+		{
+			name:     "not ready",
+			lastSync: time.Minute,
+			modify: func(syncObject *syncv1alpha1.SyncObject) {
+				syncObject.Status.Conditions[0].Status = metav1.ConditionFalse
+			},
+		},
+		{
+			name:     "spec changed",
+			lastSync: time.Minute,
+			modify:   func(syncObject *syncv1alpha1.SyncObject) { syncObject.Generation++ },
+		},
+		{
+			name:     "stale reference",
+			lastSync: time.Minute,
+			modify: func(syncObject *syncv1alpha1.SyncObject) {
+				syncObject.Status.AppliedReferences = []syncv1alpha1.Reference{
+					testRef,
+					{Version: "v1", Kind: "Secret", Name: "gone", Namespace: "origin-ns"},
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncObject := readySyncObject()
+			syncObject.Spec.ResyncInterval = metav1.Duration{Duration: time.Hour}
+			if tt.modify != nil {
+				tt.modify(&syncObject)
+			}
+			r := &SyncObjectReconciler{}
+			if tt.lastSync > 0 {
+				r.changes.synced(client.ObjectKeyFromObject(&syncObject), time.Now().Add(-tt.lastSync))
+			}
+
+			remaining, ok := r.untilResync(syncObject, tt.changes)
+			require.Equal(t, tt.want, ok)
+			if tt.want {
+				require.InDelta(t, 59*time.Minute, remaining, float64(time.Second))
+			}
+		})
+	}
+}
+
+func TestRepair(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().
+		WithRESTMapper(testMapper()).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "origin-ns"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: testRef.Namespace},
+				Data:       map[string]string{"key": "value"},
+			},
+		).
+		Build()
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	syncObject := readySyncObject()
+	results, err := r.sync(ctx, syncObject)
+	require.NoError(t, err)
+	syncObject.Status.References = results
+	require.Equal(t, int32(2), results[0].DesiredReplicas)
+
+	// replica a deleted by hand, b left alone, and c created
+	require.NoError(t, fakeClient.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: "a"}}))
+	var untouched corev1.ConfigMap
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "b", Name: testRef.Name}, &untouched))
+	require.NoError(t, fakeClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "c"}}))
+
+	repaired, err := r.repair(ctx, syncObject, map[string]namespaceChange{"a": replicaChanged, "c": namespaceChanged})
+	require.NoError(t, err)
+	for _, namespace := range []string{"a", "c"} {
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: testRef.Name}, &corev1.ConfigMap{}))
+	}
+	var b corev1.ConfigMap
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "b", Name: testRef.Name}, &b))
+	require.Equal(t, untouched.ResourceVersion, b.ResourceVersion)
+
+	require.Len(t, repaired, 1)
+	require.Equal(t, int32(3), repaired[0].DesiredReplicas, "the replica in the new namespace is counted")
+	require.Equal(t, int32(3), repaired[0].SyncedReplicas)
+	require.Len(t, repaired[0].Replicas, 3)
+	require.Equal(t, int32(2), syncObject.Status.References[0].DesiredReplicas, "the status passed in is left alone")
+
+	// a replica that failed before, and is repaired now, counts as synced
+	repaired[0].Replicas[0].State = syncv1alpha1.FailedReplicaState
+	repaired[0].SyncedReplicas--
+	repaired[0].FailedReplicas++
+	syncObject.Status.References = repaired
+	failed := repaired[0].Replicas[0].Namespace
+	repaired, err = r.repair(ctx, syncObject, map[string]namespaceChange{failed: replicaChanged})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), repaired[0].DesiredReplicas)
+	require.Equal(t, int32(3), repaired[0].SyncedReplicas)
+	require.Zero(t, repaired[0].FailedReplicas)
+
+	// a namespace created with the replica already there isn't counted twice
+	syncObject.Status.References = repaired
+	repaired, err = r.repair(ctx, syncObject, map[string]namespaceChange{"c": namespaceChanged})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), repaired[0].DesiredReplicas)
+	require.Len(t, repaired[0].Replicas, 3)
+
+	// a namespace deleted, which only the deletion of its replica tells of
+	syncObject.Status.References = repaired
+	r.cache = &clientCache{reader: fakeClient}
+	require.NoError(t, fakeClient.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: "b"}}))
+	require.NoError(t, fakeClient.Delete(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "b"}}))
+	repaired, err = r.repair(ctx, syncObject, map[string]namespaceChange{"b": replicaChanged})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), repaired[0].DesiredReplicas, "the replica in the deleted namespace isn't counted")
+	require.Equal(t, int32(2), repaired[0].SyncedReplicas)
+	require.Len(t, repaired[0].Replicas, 2)
+	for _, replica := range repaired[0].Replicas {
+		require.NotEqual(t, "b", replica.Namespace)
+	}
+
+	// it may be among those left out, which only a full sync can tell
+	syncObject.Status.References[0].OmittedReplicas = 1
+	_, err = r.repair(ctx, syncObject, map[string]namespaceChange{"b": replicaChanged})
+	require.ErrorIs(t, err, errRepairNotEnough)
+}
+
+// clientCache reads from a client, like a cache that is never behind.
+type clientCache struct {
+	cache.Cache
+	reader client.Reader
+}
+
+func (c *clientCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c *clientCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+func TestRepairNotEnough(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().
+		WithRESTMapper(testMapper()).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "origin-ns"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "excluded", Labels: map[string]string{"sync": "no"}}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: testRef.Namespace}},
+		).
+		Build()
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	syncObject := readySyncObject()
+	syncObject.Spec.TargetNamespaceSelector = &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "sync", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"no"}}},
+	}
+	results, err := r.sync(ctx, syncObject)
+	require.NoError(t, err)
+	syncObject.Status.References = results
+
+	tests := []struct {
+		name       string
+		namespaces map[string]namespaceChange
+	}{
+		{"namespace no longer targeted", map[string]namespaceChange{"excluded": namespaceChanged}},
+		{"replica left behind in a namespace not targeted", map[string]namespaceChange{"excluded": replicaChanged}},
+		{"replica and namespace changed", map[string]namespaceChange{"a": replicaChanged | namespaceChanged}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.repair(ctx, syncObject, tt.namespaces)
+			require.ErrorIs(t, err, errRepairNotEnough)
+		})
+	}
+
+	t.Run("references changed", func(t *testing.T) {
+		changed := *syncObject.DeepCopy()
+		changed.Spec.Reference = &syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Name: "other", Namespace: "origin-ns"}
+		_, err := r.repair(ctx, changed, map[string]namespaceChange{"a": replicaChanged})
+		require.ErrorIs(t, err, errRepairNotEnough)
+	})
+
+	t.Run("replica owned by someone else", func(t *testing.T) {
+		foreign := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: "a"}}
+		require.NoError(t, fakeClient.Delete(ctx, foreign))
+		require.NoError(t, fakeClient.Create(ctx, foreign))
+		_, err := r.repair(ctx, syncObject, map[string]namespaceChange{"a": replicaChanged})
+		require.ErrorIs(t, err, errRepairNotEnough, "a conflict is for the full sync to report")
+	})
+}
+
+func TestRequestsRecordChanges(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, syncv1alpha1.AddToScheme(scheme))
+
+	syncObject := testSyncObject.DeepCopy()
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(syncObject).
+		WithIndex(&syncv1alpha1.SyncObject{}, referencedObjectIndexKey, indexByReference).
+		WithIndex(&syncv1alpha1.NamespacedSyncObject{}, referencedObjectIndexKey, indexByReference).
+		Build()
+	r := &SyncObjectReconciler{Client: fakeClient}
+	key := client.ObjectKeyFromObject(syncObject)
+
+	original := &unstructured.Unstructured{}
+	original.SetGroupVersionKind(testRef.GroupVersionKind())
+	original.SetNamespace(testRef.Namespace)
+	original.SetName(testRef.Name)
+	require.Len(t, r.requestsForObject(context.Background(), original), 1)
+	require.True(t, r.changes.take(key).sources)
+
+	replica := original.DeepCopy()
+	replica.SetNamespace("target-ns")
+	markAsReplica(replica, *syncObject, client.ObjectKey{Namespace: testRef.Namespace, Name: testRef.Name})
+	require.Len(t, r.requestsForObject(context.Background(), replica), 1)
+	require.Equal(t, pendingChanges{namespaces: map[string]namespaceChange{"target-ns": replicaChanged}}, r.changes.take(key))
+
+	// an object of the same name elsewhere, such as a replica of another
+	// SyncObject, is neither
+	elsewhere := original.DeepCopy()
+	elsewhere.SetNamespace("other-ns")
+	require.Empty(t, r.requestsForObject(context.Background(), elsewhere))
+	markAsReplica(elsewhere, syncv1alpha1.SyncObject{ObjectMeta: metav1.ObjectMeta{Name: "other"}}, client.ObjectKey{Namespace: "other-ns", Name: testRef.Name})
+	require.Empty(t, r.requestsForObject(context.Background(), elsewhere))
+	require.Equal(t, pendingChanges{}, r.changes.take(key))
+
+	require.Len(t, r.requestsForNamespace(context.Background(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "new-ns"}}), 1)
+	require.Equal(t, pendingChanges{namespaces: map[string]namespaceChange{"new-ns": namespaceChanged}}, r.changes.take(key))
+}
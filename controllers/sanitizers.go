@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Sanitizer removes from a replica of one particular kind what the API
+// server allocated for the original, or what only means something in the
+// original's namespace. Copying those fails, or has the replica fight the
+// API server over them on every sync.
+//
+// It runs right after stripOriginalState, which covers what all kinds have
+// in common.
+type Sanitizer func(replica *unstructured.Unstructured) error
+
+// Sanitizers holds the Sanitizer of each kind that needs one.
+type Sanitizers map[schema.GroupKind]Sanitizer
+
+// Register adds the sanitizer of a kind, replacing any it had before.
+func (s Sanitizers) Register(groupKind schema.GroupKind, sanitizer Sanitizer) {
+	s[groupKind] = sanitizer
+}
+
+// DefaultSanitizers returns the sanitizers of the kinds built into
+// Kubernetes. Register those of other kinds on top:
+//
+//	sanitizers := controllers.DefaultSanitizers()
+//	sanitizers.Register(schema.GroupKind{Group: "example.com", Kind: "Widget"}, sanitizeWidget)
+func DefaultSanitizers() Sanitizers {
+	return Sanitizers{
+		{Group: "", Kind: "Service"}:               sanitizeService,
+		{Group: "", Kind: "PersistentVolumeClaim"}: sanitizePersistentVolumeClaim,
+		{Group: "", Kind: "Secret"}:                sanitizeSecret,
+		{Group: "", Kind: "ServiceAccount"}:        sanitizeServiceAccount,
+		{Group: "batch", Kind: "Job"}:              sanitizeJob,
+	}
+}
+
+// sanitizerFor returns the sanitizer of a kind, or nil when it has none.
+func (r *SyncObjectReconciler) sanitizerFor(groupKind schema.GroupKind) Sanitizer {
+	if r.Sanitizers == nil {
+		return defaultSanitizers[groupKind]
+	}
+	return r.Sanitizers[groupKind]
+}
+
+// defaultSanitizers is used when the reconciler wasn't given any.
+var defaultSanitizers = DefaultSanitizers()
+
+// sanitizeService drops the cluster IPs and node ports the API server
+// allocated for the original. They are unique across the cluster, so
+// creating the replica with them fails.
+//
+// A headless Service's clusterIP of "None" was chosen rather than
+// allocated, and is kept.
+func sanitizeService(replica *unstructured.Unstructured) error {
+	clusterIP, _, _ := unstructured.NestedString(replica.Object, "spec", "clusterIP")
+	if clusterIP != "None" {
+		unstructured.RemoveNestedField(replica.Object, "spec", "clusterIP")
+		unstructured.RemoveNestedField(replica.Object, "spec", "clusterIPs")
+	}
+	unstructured.RemoveNestedField(replica.Object, "spec", "healthCheckNodePort")
+
+	ports, found, err := unstructured.NestedSlice(replica.Object, "spec", "ports")
+	if err != nil || !found {
+		return err
+	}
+	for _, port := range ports {
+		if port, ok := port.(map[string]any); ok {
+			delete(port, "nodePort")
+		}
+	}
+	return unstructured.SetNestedSlice(replica.Object, ports, "spec", "ports")
+}
+
+// persistentVolumeClaimBindAnnotations are set on a claim as it is bound to
+// a volume.
+var persistentVolumeClaimBindAnnotations = []string{
+	"pv.kubernetes.io/bind-completed",
+	"pv.kubernetes.io/bound-by-controller",
+	"volume.beta.kubernetes.io/storage-provisioner",
+	"volume.kubernetes.io/storage-provisioner",
+	"volume.kubernetes.io/selected-node",
+}
+
+// sanitizePersistentVolumeClaim drops the binding to the original's volume,
+// which only one claim can be bound to, so the replica gets its own.
+func sanitizePersistentVolumeClaim(replica *unstructured.Unstructured) error {
+	unstructured.RemoveNestedField(replica.Object, "spec", "volumeName")
+	removeAnnotations(replica, persistentVolumeClaimBindAnnotations...)
+	return nil
+}
+
+// jobControllerLabels are put on a Job's pod template by the API server,
+// and point at the original Job.
+var jobControllerLabels = []string{
+	"controller-uid",
+	"batch.kubernetes.io/controller-uid",
+	"job-name",
+	"batch.kubernetes.io/job-name",
+}
+
+// sanitizeJob drops the selector the API server generated for the
+// original, which matches its pods by its UID. Unless the selector was set
+// by hand, the replica gets its own generated one.
+func sanitizeJob(replica *unstructured.Unstructured) error {
+	manualSelector, _, _ := unstructured.NestedBool(replica.Object, "spec", "manualSelector")
+	if manualSelector {
+		return nil
+	}
+
+	unstructured.RemoveNestedField(replica.Object, "spec", "selector")
+	for _, label := range jobControllerLabels {
+		unstructured.RemoveNestedField(replica.Object, "spec", "template", "metadata", "labels", label)
+	}
+	return nil
+}
+
+// sanitizeSecret drops what the token controller filled into a
+// ServiceAccount token Secret: a token of the original's ServiceAccount,
+// and its namespace. The token controller of the target namespace fills in
+// the replica's own.
+func sanitizeSecret(replica *unstructured.Unstructured) error {
+	secretType, _, _ := unstructured.NestedString(replica.Object, "type")
+	if secretType != "kubernetes.io/service-account-token" {
+		return nil
+	}
+
+	for _, key := range []string{"token", "ca.crt", "namespace"} {
+		unstructured.RemoveNestedField(replica.Object, "data", key)
+	}
+	removeAnnotations(replica, "kubernetes.io/service-account.uid")
+	return nil
+}
+
+// sanitizeServiceAccount drops the Secrets a ServiceAccount lists, which
+// are in the original's namespace.
+func sanitizeServiceAccount(replica *unstructured.Unstructured) error {
+	unstructured.RemoveNestedField(replica.Object, "secrets")
+	return nil
+}
+
+// removeAnnotations removes the annotations with the given keys.
+func removeAnnotations(obj *unstructured.Unstructured, keys ...string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		return
+	}
+	for _, key := range keys {
+		delete(annotations, key)
+	}
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDefaultSanitizers(t *testing.T) {
+	tests := []struct {
+		name   string
+		object map[string]any
+		want   map[string]any
+	}{
+		{
+			name: "Service",
+			object: map[string]any{
+				"apiVersion": "v1", "kind": "Service",
+				"spec": map[string]any{
+					"clusterIP":           "10.0.0.1",
+					"clusterIPs":          []any{"10.0.0.1"},
+					"healthCheckNodePort": int64(32000),
+					"ports":               []any{map[string]any{"port": int64(80), "nodePort": int64(30080)}},
+				},
+			},
+			want: map[string]any{
+				"apiVersion": "v1", "kind": "Service",
+				"spec": map[string]any{
+					"ports": []any{map[string]any{"port": int64(80)}},
+				},
+			},
+		},
+		{
+			name: "headless Service",
+			object: map[string]any{
+				"apiVersion": "v1", "kind": "Service",
+				"spec": map[string]any{"clusterIP": "None", "clusterIPs": []any{"None"}},
+			},
+			want: map[string]any{
+				"apiVersion": "v1", "kind": "Service",
+				"spec": map[string]any{"clusterIP": "None", "clusterIPs": []any{"None"}},
+			},
+		},
+		{
+			name: "PersistentVolumeClaim",
+			object: map[string]any{
+				"apiVersion": "v1", "kind": "PersistentVolumeClaim",
+				"metadata": map[string]any{"annotations": map[string]any{"pv.kubernetes.io/bind-completed": "yes", "keep": "me"}},
+				"spec":     map[string]any{"volumeName": "pvc-123", "storageClassName": "standard"},
+			},
+			want: map[string]any{
+				"apiVersion": "v1", "kind": "PersistentVolumeClaim",
+				"metadata": map[string]any{"annotations": map[string]any{"keep": "me"}},
+				"spec":     map[string]any{"storageClassName": "standard"},
+			},
+		},
+		{
+			name: "Job with a generated selector",
+			object: map[string]any{
+				"apiVersion": "batch/v1", "kind": "Job",
+				"spec": map[string]any{
+					"selector": map[string]any{"matchLabels": map[string]any{"batch.kubernetes.io/controller-uid": "123"}},
+					"template": map[string]any{"metadata": map[string]any{"labels": map[string]any{
+						"batch.kubernetes.io/controller-uid": "123", "controller-uid": "123",
+						"batch.kubernetes.io/job-name": "migrate", "job-name": "migrate", "app": "migrate",
+					}}},
+				},
+			},
+			want: map[string]any{
+				"apiVersion": "batch/v1", "kind": "Job",
+				"spec": map[string]any{
+					"template": map[string]any{"metadata": map[string]any{"labels": map[string]any{"app": "migrate"}}},
+				},
+			},
+		},
+		{
+			name: "Job with a manual selector",
+			object: map[string]any{
+				"apiVersion": "batch/v1", "kind": "Job",
+				"spec": map[string]any{"manualSelector": true, "selector": map[string]any{"matchLabels": map[string]any{"app": "migrate"}}},
+			},
+			want: map[string]any{
+				"apiVersion": "batch/v1", "kind": "Job",
+				"spec": map[string]any{"manualSelector": true, "selector": map[string]any{"matchLabels": map[string]any{"app": "migrate"}}},
+			},
+		},
+		{
+			name: "ServiceAccount token Secret",
+			object: map[string]any{
+				"apiVersion": "v1", "kind": "Secret", "type": "kubernetes.io/service-account-token",
+				"metadata": map[string]any{"annotations": map[string]any{"kubernetes.io/service-account.name": "builder", "kubernetes.io/service-account.uid": "123"}},
+				"data":     map[string]any{"token": "dG9rZW4=", "ca.crt": "Y2E=", "namespace": "b3JpZ2lu"},
+			},
+			want: map[string]any{
+				"apiVersion": "v1", "kind": "Secret", "type": "kubernetes.io/service-account-token",
+				"metadata": map[string]any{"annotations": map[string]any{"kubernetes.io/service-account.name": "builder"}},
+				"data":     map[string]any{},
+			},
+		},
+		{
+			name: "other Secret",
+			object: map[string]any{
+				"apiVersion": "v1", "kind": "Secret", "type": "Opaque",
+				"data": map[string]any{"token": "dG9rZW4="},
+			},
+			want: map[string]any{
+				"apiVersion": "v1", "kind": "Secret", "type": "Opaque",
+				"data": map[string]any{"token": "dG9rZW4="},
+			},
+		},
+		{
+			name: "ServiceAccount",
+			object: map[string]any{
+				"apiVersion": "v1", "kind": "ServiceAccount",
+				"secrets": []any{map[string]any{"name": "builder-token-abcde"}},
+			},
+			want: map[string]any{"apiVersion": "v1", "kind": "ServiceAccount"},
+		},
+	}
+
+	r := &SyncObjectReconciler{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replica := &unstructured.Unstructured{Object: tt.object}
+			sanitize := r.sanitizerFor(replica.GroupVersionKind().GroupKind())
+			require.NotNil(t, sanitize)
+			require.NoError(t, sanitize(replica))
+			require.Equal(t, tt.want, replica.Object)
+		})
+	}
+}
+
+func TestReplicateRunsRegisteredSanitizer(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().Build()
+
+	sanitizers := DefaultSanitizers()
+	sanitizers.Register(schema.GroupKind{Kind: "ConfigMap"}, func(replica *unstructured.Unstructured) error {
+		unstructured.RemoveNestedField(replica.Object, "data", "local")
+		return nil
+	})
+	r := &SyncObjectReconciler{Client: fakeClient, Sanitizers: sanitizers}
+
+	original := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": testRef.Name, "namespace": testRef.Namespace},
+		"data":       map[string]any{"local": "only here", "shared": "everywhere"},
+	}}
+
+	_, err := r.replicate(context.Background(), r.Client, testSyncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+
+	var replica corev1.ConfigMap
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "target-ns", Name: testRef.Name}, &replica))
+	require.Equal(t, map[string]string{"shared": "everywhere"}, replica.Data)
+}
@@ -9,12 +9,14 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	syncv1alpha1 "github.com/sj14/sync-operator/api/v1alpha1"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -182,7 +184,7 @@ func TestControllersCreatesReplica(t *testing.T) {
 				Name: "sync-test",
 			},
 			Spec: syncv1alpha1.SyncObjectSpec{
-				Reference: syncv1alpha1.Reference{
+				Reference: &syncv1alpha1.Reference{
 					Group:     "",
 					Version:   "v1",
 					Kind:      getOriginConfigMap(nil).Kind,
@@ -240,7 +242,7 @@ func TestControllersReportsStatus(t *testing.T) {
 		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
 		ObjectMeta: metav1.ObjectMeta{Name: "sync-status"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -341,7 +343,7 @@ func TestControllersDeletingSyncObjectRemovesReplicas(t *testing.T) {
 		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
 		ObjectMeta: metav1.ObjectMeta{Name: "sync-delete"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -408,7 +410,7 @@ func TestControllersDisableFinalizerKeepsReplicas(t *testing.T) {
 		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
 		ObjectMeta: metav1.ObjectMeta{Name: "sync-nofinalizer"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -475,7 +477,7 @@ func TestControllersIgnoreNamespaces(t *testing.T) {
 		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
 		ObjectMeta: metav1.ObjectMeta{Name: "sync-ignoretest"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:   "",
 				Version: "v1",
 				// originConfigMap.Kind is empty by this point: Create() clears
@@ -569,7 +571,7 @@ func TestControllersStripsOriginalStateFromReplicas(t *testing.T) {
 		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
 		ObjectMeta: metav1.ObjectMeta{Name: "sync-strip"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -636,7 +638,7 @@ func TestControllersMarksReplicas(t *testing.T) {
 		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
 		ObjectMeta: metav1.ObjectMeta{Name: "sync-marks"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -719,7 +721,7 @@ func TestControllersRefusesToReplicateAReplica(t *testing.T) {
 		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
 		ObjectMeta: metav1.ObjectMeta{Name: "sync-chain-first"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -741,7 +743,7 @@ func TestControllersRefusesToReplicateAReplica(t *testing.T) {
 		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
 		ObjectMeta: metav1.ObjectMeta{Name: "sync-chain-second"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -796,7 +798,7 @@ func TestControllersRepairsReplicaDrift(t *testing.T) {
 		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
 		ObjectMeta: metav1.ObjectMeta{Name: "sync-drift"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -876,7 +878,7 @@ func TestControllersReplicatesIntoNewNamespace(t *testing.T) {
 		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
 		ObjectMeta: metav1.ObjectMeta{Name: "sync-nswatch"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -906,6 +908,254 @@ func TestControllersReplicatesIntoNewNamespace(t *testing.T) {
 	}, timeout, interval, "a namespace created later should get its replica without waiting for the resync")
 }
 
+// TestControllersFollowsNamespaceLabels covers targetNamespaceSelector:
+// relabelling a namespace moves it in or out of scope, and only the
+// namespace watch can notice that before the 1h resync.
+func TestControllersFollowsNamespaceLabels(t *testing.T) {
+	ctx := context.Background()
+
+	originNamespace := &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "nsselector-origin-namespace"},
+	}
+	teamNamespace := &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "nsselector-team-namespace"},
+	}
+	originConfigMap := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "nsselector-configmap", Namespace: originNamespace.Name},
+		Data:       map[string]string{"key": "value"},
+	}
+
+	require.NoError(t, k8sClient.Create(ctx, originNamespace))
+	require.NoError(t, k8sClient.Create(ctx, teamNamespace))
+	require.NoError(t, k8sClient.Create(ctx, originConfigMap))
+
+	syncObject := &syncv1alpha1.SyncObject{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
+		ObjectMeta: metav1.ObjectMeta{Name: "sync-nsselector"},
+		Spec: syncv1alpha1.SyncObjectSpec{
+			Reference: &syncv1alpha1.Reference{
+				Group:     "",
+				Version:   "v1",
+				Kind:      "ConfigMap",
+				Name:      originConfigMap.Name,
+				Namespace: originNamespace.Name,
+			},
+			TargetNamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"nsselector-team": "payments"},
+			},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, syncObject))
+
+	replicaKey := client.ObjectKey{Namespace: teamNamespace.Name, Name: originConfigMap.Name}
+
+	// let the initial pass finish, which must leave the unlabelled namespace alone
+	require.Eventually(t, func() bool {
+		fetched := &syncv1alpha1.SyncObject{}
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(syncObject), fetched); err != nil {
+			return false
+		}
+		return apimeta.IsStatusConditionTrue(fetched.Status.Conditions, syncv1alpha1.ConditionReady)
+	}, timeout, interval)
+	require.True(t, apierrors.IsNotFound(k8sClient.Get(ctx, replicaKey, &corev1.ConfigMap{})),
+		"a namespace not matching the selector must not get a replica")
+
+	t.Run("a namespace labelled into scope gets a replica", func(t *testing.T) {
+		updateWithRetry(ctx, t, teamNamespace, func() {
+			teamNamespace.Labels = map[string]string{"nsselector-team": "payments"}
+		})
+
+		require.Eventually(t, func() bool {
+			return k8sClient.Get(ctx, replicaKey, &corev1.ConfigMap{}) == nil
+		}, timeout, interval, "the relabelled namespace should get its replica without waiting for the resync")
+	})
+
+	t.Run("a namespace labelled out of scope loses its replica", func(t *testing.T) {
+		updateWithRetry(ctx, t, teamNamespace, func() {
+			teamNamespace.Labels = map[string]string{"nsselector-team": "search"}
+		})
+
+		require.Eventually(t, func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, replicaKey, &corev1.ConfigMap{}))
+		}, timeout, interval, "the replica should be removed once the namespace no longer matches")
+	})
+}
+
+// TestControllersReplicatesSelectedObjects covers a reference with a
+// selector: every matching object is replicated, one that starts matching
+// later gets replicas too, and one that stops matching loses them.
+func TestControllersReplicatesSelectedObjects(t *testing.T) {
+	ctx := context.Background()
+
+	originNamespace := &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "selector-origin-namespace"},
+	}
+	targetNamespace := &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "selector-target-namespace"},
+	}
+	shared := map[string]string{"selector-test": "shared"}
+	firstOrigin := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "selector-first", Namespace: originNamespace.Name, Labels: shared},
+		Data:       map[string]string{"which": "first"},
+	}
+	secondOrigin := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "selector-second", Namespace: originNamespace.Name, Labels: shared},
+		Data:       map[string]string{"which": "second"},
+	}
+	unselected := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "selector-unselected", Namespace: originNamespace.Name},
+		Data:       map[string]string{"which": "unselected"},
+	}
+
+	require.NoError(t, k8sClient.Create(ctx, originNamespace))
+	require.NoError(t, k8sClient.Create(ctx, targetNamespace))
+	require.NoError(t, k8sClient.Create(ctx, firstOrigin))
+	require.NoError(t, k8sClient.Create(ctx, secondOrigin))
+	require.NoError(t, k8sClient.Create(ctx, unselected))
+
+	syncObject := &syncv1alpha1.SyncObject{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
+		ObjectMeta: metav1.ObjectMeta{Name: "sync-selector"},
+		Spec: syncv1alpha1.SyncObjectSpec{
+			Reference: &syncv1alpha1.Reference{
+				Group:     "",
+				Version:   "v1",
+				Kind:      "ConfigMap",
+				Selector:  &metav1.LabelSelector{MatchLabels: shared},
+				Namespace: originNamespace.Name,
+			},
+			TargetNamespaces: []string{targetNamespace.Name},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, syncObject))
+
+	replicaKey := func(name string) client.ObjectKey {
+		return client.ObjectKey{Namespace: targetNamespace.Name, Name: name}
+	}
+
+	t.Run("every matching object is replicated", func(t *testing.T) {
+		for _, origin := range []*corev1.ConfigMap{firstOrigin, secondOrigin} {
+			require.Eventually(t, func() bool {
+				replica := &corev1.ConfigMap{}
+				if err := k8sClient.Get(ctx, replicaKey(origin.Name), replica); err != nil {
+					return false
+				}
+				return replica.Data["which"] == origin.Data["which"]
+			}, timeout, interval)
+		}
+		require.True(t, apierrors.IsNotFound(k8sClient.Get(ctx, replicaKey(unselected.Name), &corev1.ConfigMap{})),
+			"an object not matching the selector must not be replicated")
+	})
+
+	t.Run("an object that starts matching is replicated", func(t *testing.T) {
+		updateWithRetry(ctx, t, unselected, func() {
+			unselected.Labels = shared
+		})
+
+		require.Eventually(t, func() bool {
+			return k8sClient.Get(ctx, replicaKey(unselected.Name), &corev1.ConfigMap{}) == nil
+		}, timeout, interval, "the newly matching object should be replicated without waiting for the resync")
+	})
+
+	t.Run("an object that stops matching loses its replicas", func(t *testing.T) {
+		updateWithRetry(ctx, t, secondOrigin, func() {
+			secondOrigin.Labels = nil
+		})
+
+		require.Eventually(t, func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, replicaKey(secondOrigin.Name), &corev1.ConfigMap{}))
+		}, timeout, interval, "the replica of an object no longer matching should be removed")
+
+		require.NoError(t, k8sClient.Get(ctx, replicaKey(firstOrigin.Name), &corev1.ConfigMap{}),
+			"the replicas of the objects still matching must stay")
+	})
+}
+
+// TestControllersReplicatesMultipleReferences covers a bundle of sources in
+// one SyncObject: all of them are replicated, and removing one entry removes
+// only that entry's replicas.
+func TestControllersReplicatesMultipleReferences(t *testing.T) {
+	ctx := context.Background()
+
+	originNamespace := &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle-origin-namespace"},
+	}
+	targetNamespace := &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle-target-namespace"},
+	}
+	originConfigMap := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle-configmap", Namespace: originNamespace.Name},
+		Data:       map[string]string{"key": "value"},
+	}
+	originSecret := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle-secret", Namespace: originNamespace.Name},
+		StringData: map[string]string{"key": "value"},
+	}
+
+	require.NoError(t, k8sClient.Create(ctx, originNamespace))
+	require.NoError(t, k8sClient.Create(ctx, targetNamespace))
+	require.NoError(t, k8sClient.Create(ctx, originConfigMap))
+	require.NoError(t, k8sClient.Create(ctx, originSecret))
+
+	configMapRef := syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Name: originConfigMap.Name, Namespace: originNamespace.Name}
+	secretRef := syncv1alpha1.Reference{Version: "v1", Kind: "Secret", Name: originSecret.Name, Namespace: originNamespace.Name}
+
+	syncObject := &syncv1alpha1.SyncObject{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
+		ObjectMeta: metav1.ObjectMeta{Name: "sync-bundle"},
+		Spec: syncv1alpha1.SyncObjectSpec{
+			References:       []syncv1alpha1.Reference{configMapRef, secretRef},
+			TargetNamespaces: []string{targetNamespace.Name},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, syncObject))
+
+	configMapKey := client.ObjectKey{Namespace: targetNamespace.Name, Name: originConfigMap.Name}
+	secretKey := client.ObjectKey{Namespace: targetNamespace.Name, Name: originSecret.Name}
+
+	t.Run("every reference is replicated", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			return k8sClient.Get(ctx, configMapKey, &corev1.ConfigMap{}) == nil &&
+				k8sClient.Get(ctx, secretKey, &corev1.Secret{}) == nil
+		}, timeout, interval)
+
+		require.Eventually(t, func() bool {
+			fetched := &syncv1alpha1.SyncObject{}
+			if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(syncObject), fetched); err != nil {
+				return false
+			}
+			return len(fetched.Status.References) == 2 &&
+				fetched.Status.References[0].Synced && fetched.Status.References[1].Synced
+		}, timeout, interval, "the status should report each reference as synced")
+	})
+
+	t.Run("removing a reference removes only its replicas", func(t *testing.T) {
+		updateWithRetry(ctx, t, syncObject, func() {
+			syncObject.Spec.References = []syncv1alpha1.Reference{configMapRef}
+		})
+
+		require.Eventually(t, func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, secretKey, &corev1.Secret{}))
+		}, timeout, interval, "the replica of the removed reference should be gone")
+
+		require.Never(t, func() bool {
+			return k8sClient.Get(ctx, configMapKey, &corev1.ConfigMap{}) != nil
+		}, 2*time.Second, interval, "the replica of the remaining reference must stay")
+	})
+}
+
 // TestControllersKeepsOriginalWhenItsNamespaceIsIgnored guards against
 // destroying the very object being synced: listing the reference's own
 // namespace under ignoreNamespaces used to make it a deletion candidate,
@@ -935,7 +1185,7 @@ func TestControllersKeepsOriginalWhenItsNamespaceIsIgnored(t *testing.T) {
 		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
 		ObjectMeta: metav1.ObjectMeta{Name: "sync-ignoreorigin"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -1002,7 +1252,7 @@ func TestControllersReplacesReplicasWhenReferenceChanges(t *testing.T) {
 		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
 		ObjectMeta: metav1.ObjectMeta{Name: "sync-refchange"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -1069,7 +1319,7 @@ func TestControllersSyncsOnReferenceChange(t *testing.T) {
 		TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
 		ObjectMeta: metav1.ObjectMeta{Name: "sync-watchtest"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -1147,7 +1397,7 @@ func TestCRDRejectsInvalidSpecs(t *testing.T) {
 
 	validSpec := func() syncv1alpha1.SyncObjectSpec {
 		return syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -1188,7 +1438,14 @@ func TestCRDRejectsInvalidSpecs(t *testing.T) {
 		{
 			name:        "empty-reference-name",
 			mutate:      func(s *syncv1alpha1.SyncObjectSpec) { s.Reference.Name = "" },
-			wantMessage: "spec.reference.name",
+			wantMessage: "exactly one of name and selector must be set",
+		},
+		{
+			name: "reference-name-and-selector",
+			mutate: func(s *syncv1alpha1.SyncObjectSpec) {
+				s.Reference.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"shared": "true"}}
+			},
+			wantMessage: "exactly one of name and selector must be set",
 		},
 		{
 			name: "namespace-in-both-lists",
@@ -1198,6 +1455,24 @@ func TestCRDRejectsInvalidSpecs(t *testing.T) {
 			},
 			wantMessage: "cannot be in both targetNamespaces and ignoreNamespaces",
 		},
+		{
+			name: "reference-and-references",
+			mutate: func(s *syncv1alpha1.SyncObjectSpec) {
+				s.References = []syncv1alpha1.Reference{*s.Reference}
+			},
+			wantMessage: "exactly one of reference and references must be set",
+		},
+		{
+			name:        "no-reference",
+			mutate:      func(s *syncv1alpha1.SyncObjectSpec) { s.Reference = nil },
+			wantMessage: "exactly one of reference and references must be set",
+		},
+		{
+			// ignoring everything leaves nothing to replicate to
+			name:        "ignore-everything",
+			mutate:      func(s *syncv1alpha1.SyncObjectSpec) { s.IgnoreNamespaces = []string{"kube-*", "*"} },
+			wantMessage: "ignoreNamespaces cannot contain '*'",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1225,8 +1500,8 @@ func TestCRDRejectsInvalidSpecs(t *testing.T) {
 	t.Run("a valid spec is accepted", func(t *testing.T) {
 		spec := validSpec()
 		spec.ResyncInterval = metav1.Duration{Duration: 30 * time.Second}
-		spec.TargetNamespaces = []string{"somewhere"}
-		spec.IgnoreNamespaces = []string{"somewhere-else"}
+		spec.TargetNamespaces = []string{"somewhere", "ci-*"}
+		spec.IgnoreNamespaces = []string{"somewhere-else", "/ci-.*-keep/"}
 
 		syncObject := &syncv1alpha1.SyncObject{
 			TypeMeta:   metav1.TypeMeta{APIVersion: "sync.sj14.github.io/v1alpha1", Kind: "SyncObject"},
@@ -1306,3 +1581,94 @@ func getOriginConfigMap(payload map[string]string) *corev1.ConfigMap {
 		Data: payload,
 	}
 }
+
+// TestControllersNamespacedSyncObjectFollowsTenantPermissions covers the
+// whole point of a NamespacedSyncObject: it replicates only where the
+// tenant's ServiceAccount may write, and never reads outside its own
+// namespace.
+func TestControllersNamespacedSyncObjectFollowsTenantPermissions(t *testing.T) {
+	ctx := context.Background()
+
+	tenantNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-namespace"}}
+	allowedNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-allowed-namespace"}}
+	forbiddenNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-forbidden-namespace"}}
+	for _, namespace := range []*corev1.Namespace{tenantNamespace, allowedNamespace, forbiddenNamespace} {
+		require.NoError(t, k8sClient.Create(ctx, namespace))
+	}
+
+	originConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-configmap", Namespace: tenantNamespace.Name},
+		Data:       map[string]string{"key": "value"},
+	}
+	require.NoError(t, k8sClient.Create(ctx, originConfigMap))
+
+	// The tenant's ServiceAccount may manage ConfigMaps in its own
+	// namespace and the allowed one, but not in the forbidden one.
+	role := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-configmaps"},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"configmaps"},
+			Verbs:     []string{"get", "list", "create", "patch", "delete"},
+		}},
+	}
+	require.NoError(t, k8sClient.Create(ctx, role))
+	for _, namespace := range []*corev1.Namespace{tenantNamespace, allowedNamespace} {
+		require.NoError(t, k8sClient.Create(ctx, &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-configmaps", Namespace: namespace.Name},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: role.Name},
+			Subjects: []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      tenantServiceAccount,
+				Namespace: tenantNamespace.Name,
+			}},
+		}))
+	}
+
+	syncObject := &syncv1alpha1.NamespacedSyncObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-sync", Namespace: tenantNamespace.Name},
+		Spec: syncv1alpha1.SyncObjectSpec{
+			Reference: &syncv1alpha1.Reference{
+				Version:   "v1",
+				Kind:      "ConfigMap",
+				Name:      originConfigMap.Name,
+				Namespace: tenantNamespace.Name,
+			},
+			TargetNamespaces: []string{allowedNamespace.Name, forbiddenNamespace.Name},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, syncObject))
+
+	t.Run("replicates where the tenant may write", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			replica := &corev1.ConfigMap{}
+			if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: allowedNamespace.Name, Name: originConfigMap.Name}, replica); err != nil {
+				return false
+			}
+			return replica.Annotations[syncObjectAnnotation] == tenantNamespace.Name+"/"+syncObject.Name
+		}, timeout, interval)
+	})
+
+	t.Run("skips where the tenant may not write", func(t *testing.T) {
+		require.Never(t, func() bool {
+			err := k8sClient.Get(ctx, client.ObjectKey{Namespace: forbiddenNamespace.Name, Name: originConfigMap.Name}, &corev1.ConfigMap{})
+			return !apierrors.IsNotFound(err)
+		}, 2*time.Second, interval)
+	})
+
+	t.Run("refuses a reference outside its namespace", func(t *testing.T) {
+		updateWithRetry(ctx, t, syncObject, func() {
+			syncObject.Spec.Reference.Namespace = allowedNamespace.Name
+		})
+
+		require.Eventually(t, func() bool {
+			fetched := &syncv1alpha1.NamespacedSyncObject{}
+			if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(syncObject), fetched); err != nil {
+				return false
+			}
+			condition := apimeta.FindStatusCondition(fetched.Status.Conditions, syncv1alpha1.ConditionReady)
+			return condition != nil && condition.Status == metav1.ConditionFalse &&
+				strings.Contains(condition.Message, "own namespace")
+		}, timeout, interval)
+	})
+}
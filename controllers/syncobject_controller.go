@@ -2,23 +2,39 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"path"
+	"regexp"
 	"slices"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	syncv1alpha1 "github.com/sj14/sync-operator/api/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/csaupgrade"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -28,6 +44,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -38,15 +55,54 @@ type SyncObjectReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 
-	// cache and dynamicController are used to lazily start a watch on a
-	// Reference's GroupVersionKind the first time it's seen, so changes to
-	// the referenced object trigger an immediate reconcile instead of only
-	// being picked up on the next periodic resync.
+	// Sanitizers clean up the replicas of particular kinds. Nil means
+	// DefaultSanitizers.
+	Sanitizers Sanitizers
+
+	// Recorder records events about the SyncObjects and their replicas.
+	// Nil records none.
+	Recorder events.EventRecorder
+	events   eventLimiter
+
+	// accessReviews are the recent answers to tenantAllowed.
+	accessReviews accessCache
+
+	// MaxConcurrentReconciles is how many SyncObjects are synced at the
+	// same time. Zero means one.
+	MaxConcurrentReconciles int
+	// MaxConcurrentWrites is how many replicas of a single SyncObject are
+	// written at the same time. Zero means one, writing them in turn.
+	MaxConcurrentWrites int
+
+	propagation propagationTracker
+	changes     changeTracker
+
+	// cache and dynamicController are used to lazily start watches on the
+	// references, so changes to the referenced objects and their replicas
+	// trigger an immediate reconcile instead of only being picked up on the
+	// next periodic resync. The cache only holds replicas, and newCache
+	// builds one for the sources of each reference.
 	cache             cache.Cache
 	dynamicController controller.Controller
-
-	watchedGVKsMu sync.Mutex
-	watchedGVKs   map[schema.GroupVersionKind]struct{}
+	newCache          func(opts cache.Options) (cache.Cache, error)
+
+	// watches are the watches started, with what stops them, and
+	// referencedWatches those each SyncObject needs, by syncObjectID. A
+	// watch no SyncObject needs anymore is stopped, see watchReferences.
+	watchesMu         sync.Mutex
+	watches           map[watchKey]func()
+	referencedWatches map[string][]watchKey
+
+	// impersonate builds a client acting as the given user, for
+	// SyncObjects acting as a ServiceAccount. The clients are kept, one per
+	// ServiceAccount, as each comes with its own connections, and
+	// impersonatedUsers is the user each SyncObject acts as, by
+	// syncObjectID. A client no SyncObject acts as anymore is dropped, see
+	// trackImpersonation.
+	impersonate           func(username string) (client.Client, error)
+	impersonatedClientsMu sync.Mutex
+	impersonatedClients   map[string]client.Client
+	impersonatedUsers     map[string]string
 }
 
 const finalizerName = "sync.sj14.github.io/finalizer"
@@ -68,17 +124,34 @@ const (
 	syncObjectAnnotation      = "sync.sj14.github.io/sync-object"
 	sourceNamespaceAnnotation = "sync.sj14.github.io/source-namespace"
 	sourceNameAnnotation      = "sync.sj14.github.io/source-name"
+
+	// Hash of what was last applied to a replica, see contentHash.
+	contentHashAnnotation = "sync.sj14.github.io/content-hash"
+
+	// fieldManager owns the fields of the replicas the operator applies.
+	fieldManager = "sync-operator"
 )
 
+// updateFieldManager is the field manager older versions of the operator
+// wrote replicas as, with plain updates: the name of its binary, which the
+// API server goes by when a client doesn't name one.
+var updateFieldManager = strings.SplitN(rest.DefaultKubernetesUserAgent(), "/", 2)[0]
+
 // stripOriginalState removes the parts of the copied object that describe the
 // original rather than the replica. What is left is the desired state: the
 // spec, data, labels and annotations the two are meant to share.
 func stripOriginalState(replica *unstructured.Unstructured) {
-	// identity of the original
+	// identity of the original, and what the API server keeps track of for
+	// it. The replica gets its own, so leaving any of them in would make it
+	// differ from the replica on every pass.
 	replica.SetResourceVersion("")
 	replica.SetUID(types.UID(""))
 	replica.SetCreationTimestamp(metav1.Time{})
 	replica.SetManagedFields(nil)
+	replica.SetGeneration(0)
+	replica.SetSelfLink("")
+	replica.SetDeletionTimestamp(nil)
+	replica.SetDeletionGracePeriodSeconds(nil)
 
 	// An owner reference to a namespaced owner only means anything within
 	// that owner's own namespace. Kubernetes treats a reference to an owner
@@ -99,17 +172,27 @@ func stripOriginalState(replica *unstructured.Unstructured) {
 	annotations := replica.GetAnnotations()
 	delete(annotations, corev1.LastAppliedConfigAnnotation)
 	replica.SetAnnotations(annotations)
+
+	// Describes the original, as observed by whatever controls it. For kinds
+	// with a status subresource the API server ignores it on writes anyway;
+	// for kinds without one the replica would be stuck with a stale copy,
+	// and differ from what a controller on the target side writes there on
+	// every pass. SyncObjectSpec.CopyStatus copies it separately.
+	unstructured.RemoveNestedField(replica.Object, "status")
 }
 
 // markAsReplica records that this object is a replica, and which SyncObject
 // and source object it came from. Existing labels and annotations copied
 // from the original are kept.
 //
+// The source is passed in rather than taken from the reference, which only
+// names it when it doesn't use a selector.
+//
 // Everything written here is derived from the SyncObject, never from the
 // current time or the cluster's state: the values have to come out
 // identical on every reconcile, otherwise each pass would be a real write,
 // which would wake the watch, which would reconcile again.
-func markAsReplica(replica *unstructured.Unstructured, syncObject syncv1alpha1.SyncObject) {
+func markAsReplica(replica *unstructured.Unstructured, syncObject syncv1alpha1.SyncObject, source client.ObjectKey) {
 	labels := replica.GetLabels()
 	if labels == nil {
 		labels = map[string]string{}
@@ -121,9 +204,9 @@ func markAsReplica(replica *unstructured.Unstructured, syncObject syncv1alpha1.S
 	if annotations == nil {
 		annotations = map[string]string{}
 	}
-	annotations[syncObjectAnnotation] = syncObject.Name
-	annotations[sourceNamespaceAnnotation] = syncObject.Spec.Reference.Namespace
-	annotations[sourceNameAnnotation] = syncObject.Spec.Reference.Name
+	annotations[syncObjectAnnotation] = syncObjectID(syncObject)
+	annotations[sourceNamespaceAnnotation] = source.Namespace
+	annotations[sourceNameAnnotation] = source.Name
 	replica.SetAnnotations(annotations)
 }
 
@@ -140,24 +223,25 @@ func (r *SyncObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	logger.Info("reconciling SyncObject")
 
-	var syncObject syncv1alpha1.SyncObject
-
-	err := r.Client.Get(ctx, req.NamespacedName, &syncObject)
+	syncObject, err := r.getSyncObject(ctx, req.NamespacedName)
 	if apierrors.IsNotFound(err) {
+		id := syncObjectID(syncv1alpha1.SyncObject{ObjectMeta: metav1.ObjectMeta{Namespace: req.Namespace, Name: req.Name}})
+		forgetSyncObject(id)
+		r.watchReferences(ctx, id, nil)
+		r.trackImpersonation(id, "")
+		r.changes.forget(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed getting SyncObject: %v", err)
 	}
 
-	if ref := syncObject.Spec.Reference; ref.Kind != "" {
-		if err := r.ensureReferenceWatch(ctx, ref); err != nil {
-			// Not fatal: resyncInterval's periodic resync still covers us, and
-			// the next Reconcile call (e.g. once the kind's CRD is installed)
-			// retries.
-			logger.Error(err, "failed to watch referenced resource for changes; falling back to periodic resync", "reference", ref)
-		}
+	r.watchReferences(ctx, syncObjectID(syncObject), syncObject.Spec.AllReferences())
+	var username string
+	if serviceAccount, impersonate, err := serviceAccountOf(syncObject); err == nil && impersonate {
+		username = serviceAccountUsername(serviceAccount)
 	}
+	r.trackImpersonation(syncObjectID(syncObject), username)
 
 	stop, err := r.handleFinalizer(ctx, &syncObject)
 	if err != nil {
@@ -167,11 +251,32 @@ func (r *SyncObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
-	syncErr := r.sync(ctx, syncObject)
+	// Unless a source changed, or a full sync is due, only what changed
+	// needs looking at.
+	changes := r.changes.take(req.NamespacedName)
+	if remaining, ok := r.untilResync(syncObject, changes); ok {
+		if len(changes.namespaces) == 0 {
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+		results, err := r.repair(ctx, syncObject, changes.namespaces)
+		if err == nil {
+			if err := r.updateStatus(ctx, &syncObject, results, nil); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+		logger.Info("syncing in full", "reason", err.Error())
+	}
+
+	r.changes.synced(req.NamespacedName, time.Now())
+	results, syncErr := r.sync(ctx, syncObject)
+	if syncErr != nil {
+		r.recordEvent(syncObject, nil, corev1.EventTypeWarning, "SyncFailed", "Sync", "%s", truncate(syncErr.Error(), maxConditionMessage))
+	}
 
 	// Recorded whatever happened, so a failure is visible in the object
 	// rather than only in the operator's logs.
-	if err := r.updateStatus(ctx, &syncObject, syncErr); err != nil {
+	if err := r.updateStatus(ctx, &syncObject, results, syncErr); err != nil {
 		return ctrl.Result{}, errors.Join(syncErr, err)
 	}
 	if syncErr != nil {
@@ -184,45 +289,378 @@ func (r *SyncObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{RequeueAfter: resyncInterval(syncObject)}, nil
 }
 
-// sync brings the replicas in line with the reference.
-func (r *SyncObjectReconciler) sync(ctx context.Context, syncObject syncv1alpha1.SyncObject) error {
+// referencePlan is what sync works out for a single reference before
+// anything is written or deleted.
+type referencePlan struct {
+	ref              syncv1alpha1.Reference
+	targetNamespaces []string
+	originals        []*unstructured.Unstructured
+	// keep is the reference's share of the replicas to leave alone when
+	// cleaning up.
+	keep keepFunc
+	// patches are the SyncObject's patches, compiled for the replicas of
+	// the reference.
+	patches       []compiledPatch
+	patchFailures []syncv1alpha1.PatchFailure
+	recreations   []syncv1alpha1.Recreation
+	conflicts     []syncv1alpha1.Conflict
+	replicas      []syncv1alpha1.ReplicaStatus
+	err           error
+}
+
+// sync brings the replicas in line with the references. It returns the
+// outcome for each reference, along with everything that went wrong.
+func (r *SyncObjectReconciler) sync(ctx context.Context, syncObject syncv1alpha1.SyncObject) ([]syncv1alpha1.ReferenceStatus, error) {
 	logger := log.FromContext(ctx)
 
-	// spec.reference was changed to point somewhere else: the replicas of the
-	// previous reference are named after it, so nothing below would ever touch
-	// them again and they'd be orphaned.
-	if applied := syncObject.Status.AppliedReference; applied != nil && *applied != syncObject.Spec.Reference {
-		logger.Info("reference changed, removing replicas of the previous reference", "previous", *applied, "current", syncObject.Spec.Reference)
-		if err := r.deleteReplicas(ctx, syncObject, *applied, nil); err != nil {
-			return fmt.Errorf("failed removing replicas of the previous reference: %v", err)
-		}
+	c, err := r.clientFor(syncObject)
+	if err != nil {
+		return nil, err
 	}
 
-	targetNamespaces, err := r.getTargetNamespaces(ctx, syncObject)
+	name, err := newReplicaNamer(syncObject.Spec.TargetName)
 	if err != nil {
-		return fmt.Errorf("failed getting target namespaces: %v", err)
+		return nil, err
+	}
+
+	// compiled once for every reference and namespace
+	patterns, patternsErr := checkNamespacePatterns(syncObject.Spec)
+
+	refs := syncObject.Spec.AllReferences()
+	plans := make([]referencePlan, 0, len(refs))
+	for _, ref := range refs {
+		if patternsErr != nil {
+			plans = append(plans, referencePlan{
+				ref: ref,
+				err: fmt.Errorf("failed getting target namespaces: %w", patternsErr),
+				// no telling which replicas are leftovers, so none of them are
+				keep: keepAllOf(ref),
+			})
+			continue
+		}
+		plans = append(plans, r.planReference(ctx, c, syncObject, patterns, ref, name))
 	}
 
 	var multiErr error
-	// cleanup leftovers, e.g. when the targetNamespaces changed
-	if err := r.deleteReplicas(ctx, syncObject, syncObject.Spec.Reference, targetNamespaces); err != nil {
-		multiErr = errors.Join(multiErr, fmt.Errorf("failed cleaning up replicas: %v", err))
+
+	// A reference was changed to point somewhere else, or removed: the
+	// replicas of the previous one are named after it, so nothing below
+	// would ever touch them again and they'd be orphaned.
+	for _, stale := range staleReferences(syncObject) {
+		logger.Info("reference changed or removed, removing replicas of the previous reference", "previous", stale)
+		if err := r.deleteReplicas(ctx, c, syncObject, stale, keepOfKind(plans, stale)); err != nil {
+			multiErr = errors.Join(multiErr, fmt.Errorf("failed removing replicas of the previous reference: %w", err))
+		}
 	}
 
-	// fetched once rather than per namespace: every replica is a copy of the
-	// same object anyway
-	original, err := r.getOriginal(ctx, syncObject.Spec.Reference)
-	if err != nil {
-		multiErr = errors.Join(multiErr, err)
-	} else {
-		for _, namespace := range targetNamespaces {
-			if err := r.replicate(ctx, syncObject, original, namespace); err != nil {
-				multiErr = errors.Join(multiErr, fmt.Errorf("failed creating replica: %v", err))
+	// Cleanup leftovers, e.g. when the targetNamespaces changed, or an object
+	// stopped matching a selector.
+	for i := range plans {
+		if err := r.deleteReplicas(ctx, c, syncObject, plans[i].ref, keepOfKind(plans, plans[i].ref)); err != nil {
+			plans[i].err = errors.Join(plans[i].err, fmt.Errorf("failed cleaning up replicas: %w", err))
+		}
+	}
+
+	// With a targetName, two sources could end up with the same replica.
+	// Writing both would make them overwrite each other on every pass.
+	// Worked out before anything is written, in order, so which of them
+	// gets the replica doesn't depend on which write finishes first.
+	var jobs []replicaJob
+	claimed := map[replicaKey]client.ObjectKey{}
+	for i := range plans {
+		for _, original := range plans[i].originals {
+			source := client.ObjectKeyFromObject(original)
+			for _, namespace := range plans[i].targetNamespaces {
+				job := replicaJob{original: original, namespace: namespace, patches: plans[i].patches}
+				job.name, job.nameErr = name(source.Name, namespace)
+				if job.nameErr == nil {
+					key := replicaKey{GroupKind: original.GroupVersionKind().GroupKind(), ObjectKey: client.ObjectKey{Namespace: namespace, Name: job.name}}
+					if other, ok := claimed[key]; ok && other != source {
+						job.claimErr = fmt.Errorf("the replicas of %s and %s would both be named %q in %q", other, source, job.name, namespace)
+					} else {
+						claimed[key] = source
+					}
+				}
+				jobs = append(jobs, job)
 			}
 		}
 	}
 
-	return multiErr
+	r.writeReplicas(ctx, c, syncObject, jobs)
+
+	// The jobs are in the order of the loops above.
+	for i := range plans {
+		// without all the target namespaces, not all replicas are known
+		planned := plans[i].err == nil
+		for _, original := range plans[i].originals {
+			synced, wrote := planned, false
+			for range plans[i].targetNamespaces {
+				job := jobs[0]
+				jobs = jobs[1:]
+				namespace, replicaName := job.namespace, job.name
+				if job.nameErr != nil {
+					plans[i].err = errors.Join(plans[i].err, job.nameErr)
+					synced = false
+					continue
+				}
+
+				replicaStatus := syncv1alpha1.ReplicaStatus{
+					Namespace:             namespace,
+					Name:                  replicaName,
+					State:                 syncv1alpha1.SyncedReplicaState,
+					SourceResourceVersion: original.GetResourceVersion(),
+				}
+
+				if job.claimErr != nil {
+					plans[i].err = errors.Join(plans[i].err, job.claimErr)
+					replicaStatus.State = syncv1alpha1.FailedReplicaState
+					replicaStatus.Message = truncate(job.claimErr.Error(), maxReplicaMessage)
+					plans[i].replicas = append(plans[i].replicas, replicaStatus)
+					synced = false
+					continue
+				}
+
+				written, err := job.written, job.err
+				var conflictErr *conflictError
+				switch {
+				case errors.As(err, &conflictErr):
+					replicaStatus.State = syncv1alpha1.ConflictReplicaState
+					replicaStatus.Message = truncate(conflictErr.message, maxReplicaMessage)
+					synced = false
+				case err != nil:
+					replicaStatus.State = syncv1alpha1.FailedReplicaState
+					replicaStatus.Message = truncate(err.Error(), maxReplicaMessage)
+					synced = false
+				case written != replicaUnchanged && written != replicaSkipped:
+					replicaStatus.LastSyncTime = job.writtenAt
+					wrote = true
+				}
+				if written != replicaSkipped {
+					plans[i].replicas = append(plans[i].replicas, replicaStatus)
+				}
+				if written == replicaRecreated {
+					reason := "ImmutableField"
+					if syncObject.Spec.UpdateStrategy == syncv1alpha1.RecreateUpdateStrategy {
+						reason = "Recreate"
+					}
+					plans[i].recreations = append(plans[i].recreations, syncv1alpha1.Recreation{
+						Namespace: namespace,
+						Name:      replicaName,
+						Reason:    reason,
+						Time:      *job.writtenAt,
+					})
+				}
+				if conflictErr != nil {
+					plans[i].conflicts = append(plans[i].conflicts, syncv1alpha1.Conflict{
+						Namespace: namespace,
+						Name:      replicaName,
+						Message:   truncate(conflictErr.message, maxReferenceMessage),
+					})
+					r.recordEvent(syncObject, nil, corev1.EventTypeWarning, "Conflict", "Apply",
+						"Skipped %s %s/%s: %s", original.GetKind(), namespace, replicaName, conflictErr.message)
+					continue
+				}
+				var patchErr *patchError
+				if errors.As(err, &patchErr) {
+					plans[i].patchFailures = append(plans[i].patchFailures, syncv1alpha1.PatchFailure{
+						Namespace: namespace,
+						Name:      replicaName,
+						Message:   truncate(patchErr.err.Error(), maxReferenceMessage),
+					})
+				}
+				if err != nil {
+					plans[i].err = errors.Join(plans[i].err, fmt.Errorf("failed creating replica: %w", err))
+				}
+			}
+			if synced {
+				r.propagation.synced(original, wrote, time.Now())
+			}
+		}
+	}
+
+	results := make([]syncv1alpha1.ReferenceStatus, 0, len(plans))
+	for _, plan := range plans {
+		result := syncv1alpha1.ReferenceStatus{Reference: plan.ref, Synced: plan.err == nil}
+		// the message still says something failed, and the replicas list
+		// the rest; this much is enough to go on
+		if limit := maxPatchFailures / len(plans); len(plan.patchFailures) > limit {
+			plan.patchFailures = plan.patchFailures[:limit]
+		}
+		result.PatchFailures = plan.patchFailures
+		if limit := maxConflicts / len(plans); len(plan.conflicts) > limit {
+			plan.conflicts = plan.conflicts[:limit]
+		}
+		result.Conflicts = plan.conflicts
+		result.Recreations = plan.recreations
+		result.DesiredReplicas, result.SyncedReplicas, result.FailedReplicas = countReplicas(plan.replicas)
+		result.Replicas, result.OmittedReplicas = limitReplicaStatuses(plan.replicas, maxReplicaStatuses/len(plans))
+		if plan.err != nil {
+			result.Message = truncate(plan.err.Error(), maxReferenceMessage)
+
+			// only worth saying which reference failed when there are several
+			if len(plans) > 1 {
+				multiErr = errors.Join(multiErr, fmt.Errorf("%s: %w", describeReference(plan.ref), plan.err))
+			} else {
+				multiErr = errors.Join(multiErr, plan.err)
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, multiErr
+}
+
+// replicaJob is a replica sync is about to write, and how writing it went.
+type replicaJob struct {
+	original  *unstructured.Unstructured
+	namespace string
+	name      string
+	patches   []compiledPatch
+	// nameErr and claimErr are why the replica isn't written at all: it
+	// couldn't be named, or another source's replica already has the name.
+	nameErr  error
+	claimErr error
+
+	written   replicaWrite
+	writtenAt *metav1.Time
+	err       error
+}
+
+// writeReplicas replicates the jobs that can be written, up to
+// MaxConcurrentWrites at a time, and records in each how that went.
+//
+// Each write is a round trip or two to the API server, so writing the
+// replicas of a source one after the other is what makes a change to it take
+// long across many namespaces. The operator's QPS limit still applies to all
+// of them together, whichever client writes them.
+func (r *SyncObjectReconciler) writeReplicas(ctx context.Context, writer client.Client, syncObject syncv1alpha1.SyncObject, jobs []replicaJob) {
+	slots := make(chan struct{}, max(r.MaxConcurrentWrites, 1))
+	var wg sync.WaitGroup
+	for i := range jobs {
+		job := &jobs[i]
+		if job.nameErr != nil || job.claimErr != nil {
+			continue
+		}
+		slots <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-slots }()
+			job.written, job.err = r.replicate(ctx, writer, syncObject, job.patches, job.original, job.namespace, job.name)
+			now := metav1.Now()
+			job.writtenAt = &now
+		})
+	}
+	wg.Wait()
+}
+
+// planReference works out the target namespaces and originals of a
+// reference, and which of its replicas to keep.
+//
+// Fetched once rather than per namespace: every replica of a source is a
+// copy of the same object anyway.
+func (r *SyncObjectReconciler) planReference(ctx context.Context, reader client.Reader, syncObject syncv1alpha1.SyncObject, patterns targetPatterns, ref syncv1alpha1.Reference, name replicaNamer) referencePlan {
+	plan := referencePlan{ref: ref}
+
+	if err := r.checkNamespaced(ref); err != nil {
+		plan.err = err
+		plan.keep = keepAllOf(ref)
+		return plan
+	}
+	if syncObject.Namespace != "" {
+		if err := r.checkTenantSource(ctx, syncObject, ref); err != nil {
+			plan.err = err
+			plan.keep = keepAllOf(ref)
+			return plan
+		}
+	}
+
+	targetNamespaces, err := r.getTargetNamespaces(ctx, syncObject, patterns, ref)
+	if err == nil && syncObject.Namespace != "" {
+		targetNamespaces, err = r.tenantTargetNamespaces(ctx, syncObject, ref, targetNamespaces)
+	}
+	if err != nil {
+		plan.err = fmt.Errorf("failed getting target namespaces: %w", err)
+		// no telling which replicas are leftovers, so none of them are
+		plan.keep = keepAllOf(ref)
+		return plan
+	}
+	plan.targetNamespaces = targetNamespaces
+	// compiled once for every replica rather than once per replica
+	plan.patches = compilePatches(syncObject.Spec.Patches)
+
+	plan.originals, plan.err = getOriginals(ctx, reader, ref)
+	var refused *sourceIsReplicaError
+	if errors.As(plan.err, &refused) {
+		r.recordEvent(syncObject, refused.source, corev1.EventTypeWarning, "RefusedReplica", "Replicate", "%s", refused.Error())
+	}
+	plan.keep = desiredReplicas(ref, targetNamespaces, plan.originals, name)
+	if plan.err != nil && ref.Selector != nil {
+		// Without the selected objects there is no telling which of their
+		// replicas are leftovers either.
+		plan.keep = keepAllOf(ref)
+	}
+
+	return plan
+}
+
+// keepOfKind combines the keepFuncs of every planned reference of ref's
+// kind. A replica of one reference can look like a replica of another --
+// a selector's sources may include an object that another reference names
+// -- so cleaning up after one must leave alone what all the others want.
+func keepOfKind(plans []referencePlan, ref syncv1alpha1.Reference) keepFunc {
+	var keeps []keepFunc
+	for _, plan := range plans {
+		if plan.ref.GroupVersionKind().GroupKind() == ref.GroupVersionKind().GroupKind() {
+			keeps = append(keeps, plan.keep)
+		}
+	}
+
+	return func(replica, source client.ObjectKey) bool {
+		return slices.ContainsFunc(keeps, func(keep keepFunc) bool {
+			return keep(replica, source)
+		})
+	}
+}
+
+// keepAllOf returns a keepFunc leaving every replica of the reference alone.
+func keepAllOf(ref syncv1alpha1.Reference) keepFunc {
+	return func(_, source client.ObjectKey) bool {
+		return source.Namespace == ref.Namespace && (ref.Selector != nil || source.Name == ref.Name)
+	}
+}
+
+// describeReference names a reference in messages.
+func describeReference(ref syncv1alpha1.Reference) string {
+	if ref.Selector != nil {
+		return fmt.Sprintf("%s %s/{%s}", ref.Kind, ref.Namespace, metav1.FormatLabelSelector(ref.Selector))
+	}
+	return fmt.Sprintf("%s %s/%s", ref.Kind, ref.Namespace, ref.Name)
+}
+
+// desiredReplicas returns the keepFunc for deleteReplicas that leaves alone
+// the replicas sync is about to create or update: those of the originals in
+// the target namespaces, under the name they are given there. A replica
+// left over from a previous targetName is removed.
+//
+// A reference by name keeps its replicas in the target namespaces even when
+// its original couldn't be fetched. An object that is briefly missing, or a
+// failed Get, is no reason to remove what was already replicated. With a
+// selector, an object that no longer matches loses its replicas.
+func desiredReplicas(ref syncv1alpha1.Reference, targetNamespaces []string, originals []*unstructured.Unstructured, name replicaNamer) keepFunc {
+	return func(replica, source client.ObjectKey) bool {
+		if source.Namespace != ref.Namespace || !slices.Contains(targetNamespaces, replica.Namespace) {
+			return false
+		}
+		if ref.Selector == nil && source.Name != ref.Name {
+			return false
+		}
+		if ref.Selector != nil && !slices.ContainsFunc(originals, func(original *unstructured.Unstructured) bool {
+			return original.GetName() == source.Name
+		}) {
+			return false
+		}
+		want, err := name(source.Name, replica.Namespace)
+		return err == nil && want == replica.Name
+	}
 }
 
 // resyncInterval returns the configured resync interval, falling back to
@@ -234,6 +672,89 @@ func resyncInterval(syncObject syncv1alpha1.SyncObject) time.Duration {
 	return syncObject.Spec.ResyncInterval.Duration
 }
 
+// getSyncObject fetches the object a request is for: a SyncObject, or a
+// NamespacedSyncObject when the request has a namespace.
+//
+// A NamespacedSyncObject is returned as a SyncObject with its namespace set.
+// The two share their spec and status, and apart from reading and writing
+// them nothing here needs to tell them apart.
+func (r *SyncObjectReconciler) getSyncObject(ctx context.Context, key client.ObjectKey) (syncv1alpha1.SyncObject, error) {
+	if key.Namespace == "" {
+		var syncObject syncv1alpha1.SyncObject
+		err := r.Client.Get(ctx, key, &syncObject)
+		return syncObject, err
+	}
+
+	var namespaced syncv1alpha1.NamespacedSyncObject
+	if err := r.Client.Get(ctx, key, &namespaced); err != nil {
+		return syncv1alpha1.SyncObject{}, err
+	}
+	return fromNamespaced(namespaced), nil
+}
+
+// listSyncObjects lists the SyncObjects and NamespacedSyncObjects matching
+// opts, the latter as SyncObjects with their namespace set.
+func (r *SyncObjectReconciler) listSyncObjects(ctx context.Context, opts ...client.ListOption) ([]syncv1alpha1.SyncObject, error) {
+	var syncObjects syncv1alpha1.SyncObjectList
+	if err := r.Client.List(ctx, &syncObjects, opts...); err != nil {
+		return nil, err
+	}
+
+	var namespaced syncv1alpha1.NamespacedSyncObjectList
+	if err := r.Client.List(ctx, &namespaced, opts...); err != nil {
+		return nil, err
+	}
+
+	all := syncObjects.Items
+	for _, item := range namespaced.Items {
+		all = append(all, fromNamespaced(item))
+	}
+	return all, nil
+}
+
+// writeSyncObject updates the SyncObject, or the status subresource when
+// status is set, as whichever kind it really is.
+func (r *SyncObjectReconciler) writeSyncObject(ctx context.Context, syncObject *syncv1alpha1.SyncObject, status bool) error {
+	var obj client.Object = syncObject
+	if syncObject.Namespace != "" {
+		obj = toNamespaced(*syncObject)
+	}
+
+	var err error
+	if status {
+		err = r.Status().Update(ctx, obj)
+	} else {
+		err = r.Update(ctx, obj)
+	}
+	if err != nil {
+		return err
+	}
+
+	if namespaced, ok := obj.(*syncv1alpha1.NamespacedSyncObject); ok {
+		// pick up the new resourceVersion
+		*syncObject = fromNamespaced(*namespaced)
+	}
+	return nil
+}
+
+func fromNamespaced(namespaced syncv1alpha1.NamespacedSyncObject) syncv1alpha1.SyncObject {
+	return syncv1alpha1.SyncObject{ObjectMeta: namespaced.ObjectMeta, Spec: namespaced.Spec, Status: namespaced.Status}
+}
+
+func toNamespaced(syncObject syncv1alpha1.SyncObject) *syncv1alpha1.NamespacedSyncObject {
+	return &syncv1alpha1.NamespacedSyncObject{ObjectMeta: syncObject.ObjectMeta, Spec: syncObject.Spec, Status: syncObject.Status}
+}
+
+// syncObjectID identifies a SyncObject in the marks on its replicas: its
+// name, or namespace/name for a NamespacedSyncObject. A name cannot contain
+// a slash, so the two can never be mistaken for each other.
+func syncObjectID(syncObject syncv1alpha1.SyncObject) string {
+	if syncObject.Namespace == "" {
+		return syncObject.Name
+	}
+	return syncObject.Namespace + "/" + syncObject.Name
+}
+
 func (r *SyncObjectReconciler) handleFinalizer(ctx context.Context, syncObject *syncv1alpha1.SyncObject) (stop bool, err error) {
 	// examine DeletionTimestamp to determine if object is under deletion
 	if syncObject.ObjectMeta.DeletionTimestamp.IsZero() {
@@ -242,7 +763,7 @@ func (r *SyncObjectReconciler) handleFinalizer(ctx context.Context, syncObject *
 		// registering our finalizer.
 		if !controllerutil.ContainsFinalizer(syncObject, finalizerName) {
 			controllerutil.AddFinalizer(syncObject, finalizerName)
-			if err := r.Update(ctx, syncObject); err != nil {
+			if err := r.writeSyncObject(ctx, syncObject, false); err != nil {
 				return true, err
 			}
 		}
@@ -253,24 +774,34 @@ func (r *SyncObjectReconciler) handleFinalizer(ctx context.Context, syncObject *
 	if controllerutil.ContainsFinalizer(syncObject, finalizerName) {
 		if !syncObject.Spec.DisableFinalizer {
 			// our finalizer is present, so lets handle any external dependency.
-			// A reference change that was never reconciled leaves replicas of
-			// the previous reference behind too, so clean up both.
-			var multiErr error
-			for _, ref := range referencesToCleanUp(*syncObject) {
-				if err := r.deleteReplicas(ctx, *syncObject, ref, nil); err != nil {
-					multiErr = errors.Join(multiErr, err)
+			c, err := r.clientFor(*syncObject)
+			if err == nil {
+				err = r.deleteAllReplicas(ctx, c, *syncObject)
+			}
+			if err != nil && (syncObject.Spec.ServiceAccountRef != nil || syncObject.Namespace != "") {
+				// The ServiceAccount is often gone first, as when the
+				// namespace of a tenant is deleted, which would then wait
+				// on this forever. Only then does the operator clean up
+				// instead; a ServiceAccount merely not allowed to delete
+				// something has to be fixed by whoever granted it.
+				gone, goneErr := r.serviceAccountGone(ctx, *syncObject)
+				if goneErr != nil {
+					err = errors.Join(err, goneErr)
+				} else if gone {
+					log.FromContext(ctx).Info("cleaning up replicas as the operator, the ServiceAccount is gone", "reason", err.Error())
+					err = r.deleteAllReplicas(ctx, r.Client, *syncObject)
 				}
 			}
-			if multiErr != nil {
+			if err != nil {
 				// if fail to delete the external dependency here, return with error
 				// so that it can be retried
-				return true, multiErr
+				return true, err
 			}
 		}
 
 		// remove our finalizer from the list and update it.
 		controllerutil.RemoveFinalizer(syncObject, finalizerName)
-		if err := r.Update(ctx, syncObject); err != nil {
+		if err := r.writeSyncObject(ctx, syncObject, false); err != nil {
 			return true, err
 		}
 
@@ -281,21 +812,110 @@ func (r *SyncObjectReconciler) handleFinalizer(ctx context.Context, syncObject *
 	return false, nil
 }
 
+// deleteAllReplicas deletes every replica of the SyncObject. A reference
+// change that was never reconciled leaves replicas of the previous reference
+// behind too, so those go as well.
+func (r *SyncObjectReconciler) deleteAllReplicas(ctx context.Context, writer client.Writer, syncObject syncv1alpha1.SyncObject) error {
+	var multiErr error
+	for _, ref := range referencesToCleanUp(syncObject) {
+		if err := r.deleteReplicas(ctx, writer, syncObject, ref, nil); err != nil {
+			multiErr = errors.Join(multiErr, err)
+		}
+	}
+	return multiErr
+}
+
+// serviceAccountGone reports whether the ServiceAccount the SyncObject acts
+// as no longer exists, or never could, lacking a namespace.
+func (r *SyncObjectReconciler) serviceAccountGone(ctx context.Context, syncObject syncv1alpha1.SyncObject) (bool, error) {
+	serviceAccount, _, err := serviceAccountOf(syncObject)
+	if err != nil {
+		return true, nil
+	}
+	// unstructured, so it is asked for rather than every ServiceAccount of
+	// the cluster cached
+	account := &unstructured.Unstructured{}
+	account.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ServiceAccount"))
+	err = r.Client.Get(ctx, serviceAccount, account)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed getting ServiceAccount %s: %w", serviceAccount, err)
+	}
+	return false, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *SyncObjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &syncv1alpha1.SyncObject{}, referencedObjectIndexKey, indexByReference); err != nil {
 		return fmt.Errorf("failed indexing SyncObject by reference: %w", err)
 	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &syncv1alpha1.NamespacedSyncObject{}, referencedObjectIndexKey, indexByReference); err != nil {
+		return fmt.Errorf("failed indexing NamespacedSyncObject by reference: %w", err)
+	}
+
+	r.newCache = func(opts cache.Options) (cache.Cache, error) {
+		opts.HTTPClient = mgr.GetHTTPClient()
+		opts.Scheme = mgr.GetScheme()
+		opts.Mapper = mgr.GetRESTMapper()
+		return cache.New(mgr.GetConfig(), opts)
+	}
+	// Only the replicas, rather than every object of the kinds referenced.
+	// Reading a kind that isn't watched fails instead of starting a watch
+	// on it, which watchReferences wouldn't know to stop.
+	replicas, err := r.newCache(cache.Options{
+		DefaultLabelSelector:        labels.SelectorFromSet(labels.Set{managedByLabel: managedByValue}),
+		ReaderFailOnMissingInformer: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed creating the cache of replicas: %w", err)
+	}
+	if err := mgr.Add(replicas); err != nil {
+		return err
+	}
+	r.cache = replicas
+	r.watches = make(map[watchKey]func())
+
+	// The caches of the sources are started and stopped as the references
+	// come and go, outside of the manager. What's left stops along with it.
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		r.watchesMu.Lock()
+		defer r.watchesMu.Unlock()
+		for _, stop := range r.watches {
+			stop()
+		}
+		return nil
+	})); err != nil {
+		return err
+	}
 
-	r.cache = mgr.GetCache()
-	r.watchedGVKs = make(map[schema.GroupVersionKind]struct{})
+	r.impersonate = func(username string) (client.Client, error) {
+		// The copy keeps the config's RateLimiter, so what is written as a
+		// ServiceAccount counts against the operator's limit too.
+		config := rest.CopyConfig(mgr.GetConfig())
+		config.Impersonate = rest.ImpersonationConfig{UserName: username}
+		// Not backed by the cache: that one is filled with the operator's
+		// own permissions, which is exactly what must not leak through.
+		return client.New(config, client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+	}
 
 	c, err := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		For(&syncv1alpha1.SyncObject{}).
-		// a namespace created later may need replicas of its own
+		// Served by the same Reconcile, which tells the two apart by
+		// whether the request has a namespace.
+		Watches(&syncv1alpha1.NamespacedSyncObject{}, &handler.EnqueueRequestForObject{}).
+		// a namespace created later may need replicas of its own, and a
+		// relabelled one may have moved in or out of a selector's scope
 		Watches(&corev1.Namespace{},
 			handler.EnqueueRequestsFromMapFunc(r.requestsForNamespace),
-			builder.WithPredicates(namespaceCreated)).
+			builder.WithPredicates(namespaceCreatedOrRelabelled)).
+		// and rendered templates may refer to its annotations
+		Watches(&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReannotatedNamespace),
+			builder.WithPredicates(namespaceReannotated)).
 		Build(r)
 	if err != nil {
 		return err
@@ -320,48 +940,192 @@ func referencedObjectKey(gvk schema.GroupVersionKind, name string) string {
 	return strings.Join([]string{gvk.Group, gvk.Version, gvk.Kind, name}, "/")
 }
 
+// referencedKindKey returns the key a reference with a selector is indexed
+// under. Any object of the kind may start matching the selector, so the key
+// covers the whole kind. "*" is not a valid object name, so it cannot clash
+// with a referencedObjectKey.
+func referencedKindKey(gvk schema.GroupVersionKind) string {
+	return referencedObjectKey(gvk, "*")
+}
+
 func indexByReference(obj client.Object) []string {
-	syncObject, ok := obj.(*syncv1alpha1.SyncObject)
-	if !ok {
+	var spec syncv1alpha1.SyncObjectSpec
+	switch syncObject := obj.(type) {
+	case *syncv1alpha1.SyncObject:
+		spec = syncObject.Spec
+	case *syncv1alpha1.NamespacedSyncObject:
+		spec = syncObject.Spec
+	default:
 		return nil
 	}
-	ref := syncObject.Spec.Reference
-	return []string{referencedObjectKey(ref.GroupVersionKind(), ref.Name)}
+
+	var keys []string
+	for _, ref := range spec.AllReferences() {
+		key := referencedObjectKey(ref.GroupVersionKind(), ref.Name)
+		if ref.Selector != nil {
+			key = referencedKindKey(ref.GroupVersionKind())
+		}
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// watchKey identifies a watch: on the replicas of a kind when only gvk is
+// set, or on the sources of a reference.
+type watchKey struct {
+	gvk schema.GroupVersionKind
+	// namespace, and name or selector, of the reference
+	namespace, name, selector string
+}
+
+// replicaWatchKey is the watch on the replicas of ref's kind.
+func replicaWatchKey(ref syncv1alpha1.Reference) watchKey {
+	return watchKey{gvk: ref.GroupVersionKind()}
+}
+
+// sourceWatchKey is the watch on the sources of ref.
+func sourceWatchKey(ref syncv1alpha1.Reference) watchKey {
+	key := watchKey{gvk: ref.GroupVersionKind(), namespace: ref.Namespace, name: ref.Name}
+	if ref.Selector != nil {
+		key.name = ""
+		key.selector = metav1.FormatLabelSelector(ref.Selector)
+	}
+	return key
 }
 
-// ensureReferenceWatch makes sure changes to objects of ref's
-// GroupVersionKind trigger a reconcile, instead of only being picked up on
-// the next periodic resync. It's a no-op once a GVK has successfully been
-// watched once.
+// watchReferences records refs as the references of the SyncObject with
+// the id, nil once it is gone. Each reference's sources and the replicas of
+// its kind are watched, and each watch no SyncObject needs anymore is
+// stopped, dropping what it cached.
 //
-// The informer behind this watch is cluster wide, so it covers the replicas
-// as well as the original: editing or deleting a replica by hand is undone
-// straight away rather than at the next resync.
-func (r *SyncObjectReconciler) ensureReferenceWatch(ctx context.Context, ref syncv1alpha1.Reference) error {
-	gvk := ref.GroupVersionKind()
+// Failing to watch is not fatal: resyncInterval's periodic resync still
+// covers us, and the next Reconcile call (e.g. once the kind's CRD is
+// installed) retries.
+func (r *SyncObjectReconciler) watchReferences(ctx context.Context, id string, refs []syncv1alpha1.Reference) {
+	logger := log.FromContext(ctx)
+
+	r.watchesMu.Lock()
+	defer r.watchesMu.Unlock()
+
+	var keys []watchKey
+	for _, ref := range refs {
+		if ref.Kind == "" {
+			continue
+		}
+		for _, key := range []watchKey{replicaWatchKey(ref), sourceWatchKey(ref)} {
+			if slices.Contains(keys, key) {
+				continue
+			}
+			keys = append(keys, key)
+			if err := r.ensureReferenceWatch(key, ref); err != nil {
+				logger.Error(err, "failed to watch referenced resource for changes; falling back to periodic resync", "reference", ref)
+			}
+		}
+	}
+
+	if r.referencedWatches == nil {
+		r.referencedWatches = map[string][]watchKey{}
+	}
+	if len(keys) == 0 {
+		delete(r.referencedWatches, id)
+	} else {
+		r.referencedWatches[id] = keys
+	}
+
+	kinds := 0
+	for key, stop := range r.watches {
+		if !r.isReferenced(key) {
+			stop()
+			delete(r.watches, key)
+			logger.Info("stopped watching what is no longer referenced", "gvk", key.gvk, "namespace", key.namespace, "name", key.name, "selector", key.selector)
+			continue
+		}
+		if key == (watchKey{gvk: key.gvk}) {
+			kinds++
+		}
+	}
+	watchedKinds.Set(float64(kinds))
+}
+
+// isReferenced reports whether any SyncObject needs the watch. The caller
+// holds watchesMu.
+func (r *SyncObjectReconciler) isReferenced(key watchKey) bool {
+	for _, keys := range r.referencedWatches {
+		if slices.Contains(keys, key) {
+			return true
+		}
+	}
+	return false
+}
 
-	r.watchedGVKsMu.Lock()
-	_, ok := r.watchedGVKs[gvk]
-	r.watchedGVKsMu.Unlock()
-	if ok {
+// ensureReferenceWatch makes sure changes to what the key identifies, of
+// ref, trigger a reconcile, instead of only being picked up on the next
+// periodic resync. It's a no-op once the watch has successfully been
+// started, until watchReferences stops it. The caller holds watchesMu.
+//
+// Only what is watched is cached, so the memory this takes grows with the
+// number of sources and replicas, not with the size of the cluster:
+//   - the replicas are watched cluster wide, but selected by their
+//     managedByLabel. Editing or deleting a replica by hand is undone
+//     straight away rather than at the next resync.
+//   - the sources of each reference are watched in their namespace, with a
+//     cache of their own, selected by name or by the reference's selector.
+func (r *SyncObjectReconciler) ensureReferenceWatch(key watchKey, ref syncv1alpha1.Reference) error {
+	if _, ok := r.watches[key]; ok {
 		return nil
 	}
 
 	watched := &unstructured.Unstructured{}
-	watched.SetGroupVersionKind(gvk)
+	watched.SetGroupVersionKind(key.gvk)
+
+	watchCache := r.cache
+	stop := func() {
+		if err := r.cache.RemoveInformer(context.Background(), watched); err != nil {
+			log.Log.Error(err, "failed to stop watching replicas", "gvk", key.gvk)
+		}
+	}
+	if key != replicaWatchKey(ref) {
+		opts := cache.Options{
+			DefaultNamespaces:    map[string]cache.Config{ref.Namespace: {}},
+			DefaultFieldSelector: fields.OneTermEqualSelector("metadata.name", ref.Name),
+		}
+		if ref.Selector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(ref.Selector)
+			if err != nil {
+				return fmt.Errorf("invalid selector: %w", err)
+			}
+			opts.DefaultFieldSelector = nil
+			opts.DefaultLabelSelector = selector
+		}
+		sourceCache, err := r.newCache(opts)
+		if err != nil {
+			return fmt.Errorf("failed creating cache for %s: %w", describeReference(ref), err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			if err := sourceCache.Start(ctx); err != nil {
+				log.Log.Error(err, "failed watching sources", "reference", ref)
+			}
+		}()
+		watchCache, stop = sourceCache, cancel
+	}
 
 	mapFn := func(ctx context.Context, obj *unstructured.Unstructured) []reconcile.Request {
 		return r.requestsForObject(ctx, obj)
 	}
 
-	src := source.Kind(r.cache, watched, handler.TypedEnqueueRequestsFromMapFunc[*unstructured.Unstructured, reconcile.Request](mapFn))
+	src := source.Kind(watchCache, watched, handler.TypedEnqueueRequestsFromMapFunc[*unstructured.Unstructured, reconcile.Request](mapFn))
 	if err := r.dynamicController.Watch(src); err != nil {
-		return fmt.Errorf("failed watching %s: %w", gvk, err)
+		stop()
+		return fmt.Errorf("failed watching %s: %w", key.gvk, err)
 	}
 
-	r.watchedGVKsMu.Lock()
-	r.watchedGVKs[gvk] = struct{}{}
-	r.watchedGVKsMu.Unlock()
+	if r.watches == nil {
+		r.watches = map[watchKey]func(){}
+	}
+	r.watches[key] = stop
 
 	return nil
 }
@@ -369,34 +1133,113 @@ func (r *SyncObjectReconciler) ensureReferenceWatch(ctx context.Context, ref syn
 // requestsForObject finds the SyncObjects (if any) that manage obj, whether
 // it's their original or one of its replicas, so a change to either
 // triggers a reconcile immediately instead of waiting for the resync.
+//
+// A reference with a selector is only interested in the objects of its
+// kind that match the selector in its source namespace, and in its own
+// replicas. For an object that was relabelled this is called both before
+// and after the change, so an object that stopped matching is covered too.
+//
+// A replica renamed by a targetName is looked up by the name of its source
+// instead, which its marks record.
 func (r *SyncObjectReconciler) requestsForObject(ctx context.Context, obj *unstructured.Unstructured) []reconcile.Request {
-	key := referencedObjectKey(obj.GroupVersionKind(), obj.GetName())
+	logger := log.FromContext(ctx)
 
-	var syncObjects syncv1alpha1.SyncObjectList
-	if err := r.Client.List(ctx, &syncObjects, client.MatchingFields{referencedObjectIndexKey: key}); err != nil {
-		log.FromContext(ctx).Error(err, "failed listing SyncObjects for object", "gvk", obj.GroupVersionKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
-		return nil
+	keys := []string{referencedObjectKey(obj.GroupVersionKind(), obj.GetName()), referencedKindKey(obj.GroupVersionKind())}
+	if obj.GetLabels()[managedByLabel] == managedByValue {
+		if source := sourceOf(obj); source.Name != obj.GetName() {
+			keys = append(keys, referencedObjectKey(obj.GroupVersionKind(), source.Name))
+		}
 	}
 
-	requests := make([]reconcile.Request, 0, len(syncObjects.Items))
-	for _, syncObject := range syncObjects.Items {
-		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&syncObject)})
+	var requests []reconcile.Request
+	for _, key := range keys {
+		syncObjects, err := r.listSyncObjects(ctx, client.MatchingFields{referencedObjectIndexKey: key})
+		if err != nil {
+			logger.Error(err, "failed listing SyncObjects for object", "gvk", obj.GroupVersionKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+			return nil
+		}
+
+		for _, syncObject := range syncObjects {
+			if !referencesObject(syncObject, obj) {
+				continue
+			}
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&syncObject)}
+			if isOwnReplica(obj, syncObject) {
+				r.changes.changed(request.NamespacedName, obj.GetNamespace(), replicaChanged)
+			} else {
+				r.changes.sourceChanged(request.NamespacedName)
+			}
+			if !slices.Contains(requests, request) {
+				requests = append(requests, request)
+			}
+		}
 	}
 	return requests
 }
 
-// namespaceCreated limits the namespace watch to namespaces appearing.
+// referencesObject reports whether any of the SyncObject's references is
+// interested in obj, as either a source or a replica.
+func referencesObject(syncObject syncv1alpha1.SyncObject, obj *unstructured.Unstructured) bool {
+	return slices.ContainsFunc(syncObject.Spec.AllReferences(), func(ref syncv1alpha1.Reference) bool {
+		if ref.GroupVersionKind() != obj.GroupVersionKind() {
+			return false
+		}
+		if ref.Selector == nil {
+			// The index matches the original and its replicas by name. Of
+			// whatever else shares it, such as the replicas of another
+			// SyncObject, none is of interest.
+			return (ref.Namespace == obj.GetNamespace() && ref.Name == obj.GetName()) || isReplicaOf(obj, syncObject, ref)
+		}
+		return isReplicaOf(obj, syncObject, ref) || selectsObject(ref, obj)
+	})
+}
+
+// selectsObject reports whether obj is in the reference's namespace and
+// matches its selector.
+func selectsObject(ref syncv1alpha1.Reference, obj client.Object) bool {
+	if obj.GetNamespace() != ref.Namespace {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(ref.Selector)
+	if err != nil {
+		// reported by the reconcile, which fails on it
+		return false
+	}
+	return selector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// namespaceCreatedOrRelabelled limits the namespace watch to namespaces
+// appearing or having their labels changed.
+//
+// A new namespace may need replicas, and so may a relabelled one that now
+// matches a targetNamespaceSelector -- or it may need its replicas removed,
+// because it stopped matching.
 //
-// A new namespace may need replicas. Nothing else about a namespace changes
-// what we would do with it: an update never makes one newly eligible, and
+// Nothing else about a namespace changes what we would do with it, and
 // when one is deleted its replicas go with it. Namespaces are updated often
-// enough -- during termination, or by anything labelling them -- that
-// reacting to that would re-list every SyncObject for nothing.
+// enough, during termination for example, that reacting to every update
+// would re-list every SyncObject for nothing.
 //
 // An informer delivers its initial list as creations, so the namespaces that
 // already exist are still covered when the operator starts.
-var namespaceCreated = predicate.Funcs{
-	UpdateFunc:  func(event.UpdateEvent) bool { return false },
+var namespaceCreatedOrRelabelled = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !maps.Equal(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+	},
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// namespaceReannotated limits the second namespace watch to namespaces
+// whose annotations changed while their labels did not; a change to both
+// is left to namespaceCreatedOrRelabelled. Only renderTemplates reads
+// annotations, so this one goes to requestsForReannotatedNamespace.
+var namespaceReannotated = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return maps.Equal(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) &&
+			!maps.Equal(e.ObjectOld.GetAnnotations(), e.ObjectNew.GetAnnotations())
+	},
 	DeleteFunc:  func(event.DeleteEvent) bool { return false },
 	GenericFunc: func(event.GenericEvent) bool { return false },
 }
@@ -404,57 +1247,563 @@ var namespaceCreated = predicate.Funcs{
 // requestsForNamespace enqueues the SyncObjects that would replicate into
 // the given namespace, so a namespace created after the fact gets its
 // replicas immediately instead of at the next resync.
+//
+// For a relabelled namespace this is called with the namespace both before
+// and after the change, which also enqueues the SyncObjects it no longer
+// matches, so they remove their replicas from it.
 func (r *SyncObjectReconciler) requestsForNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
-	var syncObjects syncv1alpha1.SyncObjectList
-	if err := r.Client.List(ctx, &syncObjects); err != nil {
-		log.FromContext(ctx).Error(err, "failed listing SyncObjects for namespace", "namespace", namespace.GetName())
+	return r.namespaceRequests(ctx, namespace, false)
+}
+
+// requestsForReannotatedNamespace is requestsForNamespace for a namespace
+// whose annotations changed: whether it is a target stays the same, so only
+// the SyncObjects rendering templates, which may refer to the annotations,
+// have anything to do.
+func (r *SyncObjectReconciler) requestsForReannotatedNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
+	return r.namespaceRequests(ctx, namespace, true)
+}
+
+func (r *SyncObjectReconciler) namespaceRequests(ctx context.Context, namespace client.Object, templatesOnly bool) []reconcile.Request {
+	logger := log.FromContext(ctx)
+
+	syncObjects, err := r.listSyncObjects(ctx)
+	if err != nil {
+		logger.Error(err, "failed listing SyncObjects for namespace", "namespace", namespace.GetName())
 		return nil
 	}
 
 	var requests []reconcile.Request
-	for _, syncObject := range syncObjects.Items {
-		targets := syncObject.Spec.TargetNamespaces
-		// an empty target list means "every namespace", so this one counts too
-		if len(targets) > 0 && !slices.Contains(targets, namespace.GetName()) {
+	for _, syncObject := range syncObjects {
+		if templatesOnly && !syncObject.Spec.RenderTemplates {
 			continue
 		}
-		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&syncObject)})
-	}
-	return requests
-}
-
-// getTargetNamespaces returns the namespaces to replicate the reference
+		patterns, err := checkNamespacePatterns(syncObject.Spec)
+		if err != nil {
+			logger.Error(err, "failed matching namespace", "namespace", namespace.GetName(), "syncObject", syncObject.Name)
+			continue
+		}
+		selected, err := selectsNamespace(syncObject, patterns, namespace)
+		if err != nil {
+			logger.Error(err, "failed matching namespace", "namespace", namespace.GetName(), "syncObject", syncObject.Name)
+			continue
+		}
+		if !selected {
+			continue
+		}
+		request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&syncObject)}
+		r.changes.changed(request.NamespacedName, namespace.GetName(), namespaceChanged)
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+// selectsNamespace reports whether the SyncObject's targetNamespaces or
+// targetNamespaceSelector cover the given namespace. ignoreNamespaces is
+// left to getTargetNamespaces: this only decides whether the namespace is
+// worth a reconcile.
+func selectsNamespace(syncObject syncv1alpha1.SyncObject, patterns targetPatterns, namespace client.Object) (bool, error) {
+	selector := syncObject.Spec.TargetNamespaceSelector
+
+	// neither is set, which means "every namespace", so this one counts too
+	if len(syncObject.Spec.TargetNamespaces) == 0 && selector == nil {
+		return true, nil
+	}
+
+	if patterns.targets.matches(namespace.GetName()) {
+		return true, nil
+	}
+	if selector == nil {
+		return false, nil
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid targetNamespaceSelector: %v", err)
+	}
+	return labelSelector.Matches(labels.Set(namespace.GetLabels())), nil
+}
+
+// getTargetNamespaces returns the namespaces to replicate the reference
 // into. Replicas anywhere else are cleaned up by deleteReplicas, which
 // finds them by their marks rather than by namespace.
 //
 // The reference's own namespace is never a target: it holds the original,
 // which must not be overwritten by a replica of itself, not even when the
 // user listed that namespace explicitly.
-func (r *SyncObjectReconciler) getTargetNamespaces(ctx context.Context, syncObject syncv1alpha1.SyncObject) ([]string, error) {
-	// cloned: appending to a spec field's slice could otherwise write into
-	// the SyncObject's own backing array.
-	targetNamespaces := slices.Clone(syncObject.Spec.TargetNamespaces)
-
-	// no namespaces defined, sync to all of them
-	if len(targetNamespaces) == 0 {
-		var allNamespaces corev1.NamespaceList
-		if err := r.Client.List(ctx, &allNamespaces); err != nil {
-			return nil, fmt.Errorf("failed listing namespaces: %v", err)
-		}
-		for _, namespace := range allNamespaces.Items {
-			if isTerminating(namespace) {
-				continue
+//
+// The patterns are those checkNamespacePatterns compiled from the spec.
+func (r *SyncObjectReconciler) getTargetNamespaces(ctx context.Context, syncObject syncv1alpha1.SyncObject, patterns targetPatterns, ref syncv1alpha1.Reference) ([]string, error) {
+	spec := syncObject.Spec
+
+	var targetNamespaces []string
+	if len(spec.TargetNamespaces) == 0 && spec.TargetNamespaceSelector == nil {
+		// no namespaces defined, sync to all of them
+		namespaces, err := r.listNamespaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, namespace := range namespaces {
+			targetNamespaces = append(targetNamespaces, namespace.Name)
+		}
+	} else {
+		// Plain names are used as they are, whether the namespace exists or
+		// not: creating the replica is what reports a namespace that
+		// doesn't.
+		for _, entry := range spec.TargetNamespaces {
+			if isNamespaceName(entry) && !slices.Contains(targetNamespaces, entry) {
+				targetNamespaces = append(targetNamespaces, entry)
+			}
+		}
+
+		// Patterns and the selector can only be resolved against the
+		// namespaces that actually exist.
+		if spec.TargetNamespaceSelector != nil || slices.ContainsFunc(spec.TargetNamespaces, func(entry string) bool { return !isNamespaceName(entry) }) {
+			namespaces, err := r.listNamespaces(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, namespace := range namespaces {
+				if slices.Contains(targetNamespaces, namespace.Name) {
+					continue
+				}
+				selected, err := selectsNamespace(syncObject, patterns, &namespace)
+				if err != nil {
+					return nil, err
+				}
+				if selected {
+					targetNamespaces = append(targetNamespaces, namespace.Name)
+				}
+			}
+		}
+	}
+
+	// Remove namespaces we want to ignore.
+	targetNamespaces = slices.DeleteFunc(targetNamespaces, patterns.ignores.matches)
+
+	return remove(targetNamespaces, ref.Namespace), nil
+}
+
+// tenantServiceAccount is the ServiceAccount a NamespacedSyncObject acts
+// as, unless it names another in its serviceAccountRef: it can only read
+// what this ServiceAccount of its namespace may read, and only write where
+// it may write.
+const tenantServiceAccount = "sync-operator"
+
+// serviceAccountOf returns the ServiceAccount the SyncObject acts as, and
+// whether it acts as one at all rather than as the operator.
+//
+// A NamespacedSyncObject always does. An access review only answers for
+// RBAC: writing as the operator would get around the API server's checks
+// for escalation, such as binding a Role with more permissions than the
+// tenant has, and the admission policies that go by who is writing.
+func serviceAccountOf(syncObject syncv1alpha1.SyncObject) (client.ObjectKey, bool, error) {
+	ref := syncObject.Spec.ServiceAccountRef
+	if ref == nil {
+		if syncObject.Namespace != "" {
+			return client.ObjectKey{Namespace: syncObject.Namespace, Name: tenantServiceAccount}, true, nil
+		}
+		return client.ObjectKey{}, false, nil
+	}
+
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = syncObject.Namespace
+	}
+	if namespace == "" {
+		return client.ObjectKey{}, false, errors.New("serviceAccountRef.namespace is required for a SyncObject")
+	}
+	if syncObject.Namespace != "" && namespace != syncObject.Namespace {
+		return client.ObjectKey{}, false, fmt.Errorf("a NamespacedSyncObject can only use a ServiceAccount of its own namespace %q, not of %q", syncObject.Namespace, namespace)
+	}
+	return client.ObjectKey{Namespace: namespace, Name: ref.Name}, true, nil
+}
+
+// serviceAccountUsername is the name a ServiceAccount authenticates as.
+func serviceAccountUsername(serviceAccount client.ObjectKey) string {
+	return "system:serviceaccount:" + serviceAccount.Namespace + ":" + serviceAccount.Name
+}
+
+// clientFor returns the client to read the sources and write the replicas
+// of the SyncObject with: the operator's own, or one impersonating the
+// ServiceAccount it acts as.
+func (r *SyncObjectReconciler) clientFor(syncObject syncv1alpha1.SyncObject) (client.Client, error) {
+	serviceAccount, impersonate, err := serviceAccountOf(syncObject)
+	if err != nil || !impersonate {
+		return r.Client, err
+	}
+	username := serviceAccountUsername(serviceAccount)
+
+	r.impersonatedClientsMu.Lock()
+	defer r.impersonatedClientsMu.Unlock()
+
+	if c, ok := r.impersonatedClients[username]; ok {
+		return c, nil
+	}
+	if r.impersonate == nil {
+		return nil, errors.New("impersonating a ServiceAccount is not set up")
+	}
+	c, err := r.impersonate(username)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating a client for ServiceAccount %s: %v", serviceAccount, err)
+	}
+	if r.impersonatedClients == nil {
+		r.impersonatedClients = make(map[string]client.Client)
+	}
+	r.impersonatedClients[username] = c
+	return c, nil
+}
+
+// trackImpersonation records the user the SyncObject with the id acts as,
+// "" when it acts as the operator or is gone, and drops the client of each
+// user no SyncObject acts as anymore. A SyncObject acting as it again gets
+// a new one from clientFor.
+func (r *SyncObjectReconciler) trackImpersonation(id, username string) {
+	r.impersonatedClientsMu.Lock()
+	defer r.impersonatedClientsMu.Unlock()
+
+	if r.impersonatedUsers == nil {
+		r.impersonatedUsers = map[string]string{}
+	}
+	if username == "" {
+		delete(r.impersonatedUsers, id)
+	} else {
+		r.impersonatedUsers[id] = username
+	}
+
+	inUse := map[string]bool{}
+	for _, username := range r.impersonatedUsers {
+		inUse[username] = true
+	}
+	for username := range r.impersonatedClients {
+		if !inUse[username] {
+			delete(r.impersonatedClients, username)
+		}
+	}
+}
+
+// checkNamespaced refuses a reference to a kind that isn't namespaced. Its
+// replicas would all be the same object, whichever namespace each is for.
+// Worse, the namespace a NamespacedSyncObject is confined to means nothing
+// to such a kind: the access reviews of checkTenantSource would be answered
+// by the tenant's own Roles, while the operator reads and writes the
+// objects cluster-wide.
+func (r *SyncObjectReconciler) checkNamespaced(ref syncv1alpha1.Reference) error {
+	gvk := ref.GroupVersionKind()
+	mapping, err := r.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return fmt.Errorf("failed mapping %s to its resource: %v", gvk, err)
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return fmt.Errorf("%s is not namespaced, only namespaced kinds can be synced", gvk.Kind)
+	}
+	return nil
+}
+
+// checkTenantSource refuses a reference of a NamespacedSyncObject to
+// anything outside its own namespace, or to a kind its tenant is not allowed
+// to read. The operator itself can read everything, so without this any
+// tenant could copy any other tenant's Secrets to where they can read them.
+func (r *SyncObjectReconciler) checkTenantSource(ctx context.Context, syncObject syncv1alpha1.SyncObject, ref syncv1alpha1.Reference) error {
+	if ref.Namespace != syncObject.Namespace {
+		return fmt.Errorf("a NamespacedSyncObject can only reference objects in its own namespace %q, not in %q", syncObject.Namespace, ref.Namespace)
+	}
+
+	serviceAccount, _, err := serviceAccountOf(syncObject)
+	if err != nil {
+		return err
+	}
+
+	verbs := []string{"get"}
+	if ref.Selector != nil {
+		verbs = append(verbs, "list")
+	}
+	allowed, err := r.tenantAllowed(ctx, serviceAccount, ref.GroupVersionKind(), ref.Namespace, "", verbs...)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apierrors.NewForbidden(schema.GroupResource{}, "", fmt.Errorf("ServiceAccount %s is not allowed to read %s", serviceAccount, ref.Kind))
+	}
+	return nil
+}
+
+// tenantTargetNamespaces narrows the target namespaces of a
+// NamespacedSyncObject down to those its tenant is allowed to write the
+// reference's kind to. The others are left out rather than reported: by
+// default every namespace is a target, and a tenant is rarely allowed to
+// write to all of them.
+func (r *SyncObjectReconciler) tenantTargetNamespaces(ctx context.Context, syncObject syncv1alpha1.SyncObject, ref syncv1alpha1.Reference, targetNamespaces []string) ([]string, error) {
+	logger := log.FromContext(ctx)
+
+	serviceAccount, _, err := serviceAccountOf(syncObject)
+	if err != nil {
+		return nil, err
+	}
+
+	var allowed []string
+	for _, namespace := range targetNamespaces {
+		ok, err := r.tenantAllowed(ctx, serviceAccount, ref.GroupVersionKind(), namespace, "", "create", "patch", "delete")
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			logger.V(1).Info("skipping namespace the tenant cannot write to", "namespace", namespace, "kind", ref.Kind)
+			continue
+		}
+		allowed = append(allowed, namespace)
+	}
+	return allowed, nil
+}
+
+// tenantAllowed asks the API server whether the tenant's ServiceAccount may
+// perform all of verbs on the kind, or on its subresource if one is given,
+// in namespace.
+//
+// The answers are kept for accessReviewTTL: every reconcile asks the same
+// for every target namespace, which would otherwise be several round trips
+// per namespace before anything is written.
+func (r *SyncObjectReconciler) tenantAllowed(ctx context.Context, serviceAccount client.ObjectKey, gvk schema.GroupVersionKind, namespace, subresource string, verbs ...string) (bool, error) {
+	mapping, err := r.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return false, fmt.Errorf("failed mapping %s to its resource: %v", gvk, err)
+	}
+
+	for _, verb := range verbs {
+		key := accessKey{
+			user:        serviceAccountUsername(serviceAccount),
+			namespace:   namespace,
+			resource:    mapping.Resource,
+			subresource: subresource,
+			verb:        verb,
+		}
+		allowed, ok := r.accessReviews.get(key, time.Now())
+		if !ok {
+			review := &authorizationv1.SubjectAccessReview{
+				Spec: authorizationv1.SubjectAccessReviewSpec{
+					User: key.user,
+					Groups: []string{
+						"system:serviceaccounts",
+						"system:serviceaccounts:" + serviceAccount.Namespace,
+						"system:authenticated",
+					},
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace:   namespace,
+						Verb:        verb,
+						Group:       mapping.Resource.Group,
+						Version:     mapping.Resource.Version,
+						Resource:    mapping.Resource.Resource,
+						Subresource: subresource,
+					},
+				},
+			}
+			if err := r.Create(ctx, review); err != nil {
+				return false, fmt.Errorf("failed reviewing access of ServiceAccount %s: %v", serviceAccount, err)
 			}
-			targetNamespaces = append(targetNamespaces, namespace.GetName())
+			allowed = review.Status.Allowed
+			r.accessReviews.put(key, allowed, time.Now())
+		}
+		if !allowed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// accessReviewTTL is how long tenantAllowed relies on an answer of the API
+// server. Permissions granted or revoked take up to this long to be noticed.
+const accessReviewTTL = time.Minute
+
+// maxAccessReviews is how many answers accessCache remembers before it
+// forgets those older than accessReviewTTL.
+const maxAccessReviews = 10000
+
+// accessKey is a question tenantAllowed asks the API server.
+type accessKey struct {
+	user        string
+	namespace   string
+	resource    schema.GroupVersionResource
+	subresource string
+	verb        string
+}
+
+// accessDecision is an answer of the API server, and when it was given.
+type accessDecision struct {
+	allowed bool
+	at      time.Time
+}
+
+// accessCache keeps the answers to tenantAllowed for accessReviewTTL. The
+// zero value is ready to use.
+type accessCache struct {
+	mu        sync.Mutex
+	decisions map[accessKey]accessDecision
+}
+
+// get returns the answer to the question, and whether there still is one.
+func (c *accessCache) get(key accessKey, now time.Time) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	decision, ok := c.decisions[key]
+	if !ok || now.Sub(decision.at) >= accessReviewTTL {
+		return false, false
+	}
+	return decision.allowed, true
+}
+
+// put records the answer to the question.
+func (c *accessCache) put(key accessKey, allowed bool, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.decisions == nil {
+		c.decisions = map[accessKey]accessDecision{}
+	}
+	if len(c.decisions) >= maxAccessReviews {
+		maps.DeleteFunc(c.decisions, func(_ accessKey, decision accessDecision) bool {
+			return now.Sub(decision.at) >= accessReviewTTL
+		})
+	}
+	c.decisions[key] = accessDecision{allowed: allowed, at: now}
+}
+
+// targetPatterns are the targetNamespaces and ignoreNamespaces of a
+// SyncObject, compiled once for every namespace they are matched against.
+type targetPatterns struct {
+	targets namespacePatterns
+	ignores namespacePatterns
+}
+
+// checkNamespacePatterns compiles the entries of targetNamespaces and
+// ignoreNamespaces. It reports those that are not valid patterns, and a
+// contradiction the CRD's validation cannot catch: a target listed by name
+// that an ignore pattern excludes anyway.
+func checkNamespacePatterns(spec syncv1alpha1.SyncObjectSpec) (targetPatterns, error) {
+	targets, err := compileNamespacePatterns(spec.TargetNamespaces)
+	if err != nil {
+		return targetPatterns{}, err
+	}
+	ignores, err := compileNamespacePatterns(spec.IgnoreNamespaces)
+	if err != nil {
+		return targetPatterns{}, err
+	}
+	// The CRD only rejects '*' itself, not the other ways of writing it.
+	for _, ignore := range ignores {
+		if ignore.matchesEveryNamespace() {
+			return targetPatterns{}, fmt.Errorf("ignoreNamespaces entry %q would exclude every namespace", ignore.entry)
 		}
 	}
 
-	// Remove namespaces we want to ignore
-	for _, ignoreNamespace := range syncObject.Spec.IgnoreNamespaces {
-		targetNamespaces = remove(targetNamespaces, ignoreNamespace)
+	for _, target := range spec.TargetNamespaces {
+		if !isNamespaceName(target) {
+			continue
+		}
+		if ignore, ignored := ignores.match(target); ignored {
+			return targetPatterns{}, fmt.Errorf("targetNamespaces entry %q is excluded by ignoreNamespaces entry %q", target, ignore)
+		}
 	}
 
-	return remove(targetNamespaces, syncObject.Spec.Reference.Namespace), nil
+	return targetPatterns{targets: targets, ignores: ignores}, nil
+}
+
+// namespacePattern is a compiled entry of targetNamespaces,
+// ignoreNamespaces or the namespaces of a patch.
+type namespacePattern struct {
+	entry string
+	// re is the regular expression of an entry wrapped in slashes, nil for
+	// a glob
+	re *regexp.Regexp
+}
+
+type namespacePatterns []namespacePattern
+
+// compileNamespacePatterns compiles the entries for matching namespaces.
+//
+// An entry wrapped in slashes, like /tenant-[a-z]+-prod/, is a regular
+// expression, which has to match the whole name. Anything else is a glob as
+// understood by path.Match, like ci-*. A plain name is a glob without any
+// wildcards, so it only matches itself.
+func compileNamespacePatterns(entries []string) (namespacePatterns, error) {
+	patterns := make(namespacePatterns, 0, len(entries))
+	for _, entry := range entries {
+		pattern := namespacePattern{entry: entry}
+		var err error
+		if expr, ok := regexpEntry(entry); ok {
+			pattern.re, err = regexp.Compile(`^(?:` + expr + `)$`)
+		} else {
+			// path.Match checks the whole pattern, whatever the name
+			_, err = path.Match(entry, "")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace pattern %q: %v", entry, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// match returns the first entry matching the namespace, and whether there
+// was one.
+func (p namespacePatterns) match(namespace string) (string, bool) {
+	for _, pattern := range p {
+		var matched bool
+		if pattern.re != nil {
+			matched = pattern.re.MatchString(namespace)
+		} else {
+			// checked when compiled
+			matched, _ = path.Match(pattern.entry, namespace)
+		}
+		if matched {
+			return pattern.entry, true
+		}
+	}
+	return "", false
+}
+
+// everyNamespace stands for the names a namespace can have: short and
+// long, with digits and dashes, and those every cluster has.
+var everyNamespace = []string{"a", "0", "a-0", "z9", "default", "kube-system", strings.Repeat("x", 63)}
+
+// matchesEveryNamespace reports whether the pattern matches any name a
+// namespace can have, going by everyNamespace.
+func (p namespacePattern) matchesEveryNamespace() bool {
+	for _, namespace := range everyNamespace {
+		if !(namespacePatterns{p}).matches(namespace) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether any of the entries matches the namespace.
+func (p namespacePatterns) matches(namespace string) bool {
+	_, matched := p.match(namespace)
+	return matched
+}
+
+// regexpEntry returns the regular expression of an entry wrapped in
+// slashes, and whether it was one.
+func regexpEntry(entry string) (string, bool) {
+	if len(entry) < 2 || !strings.HasPrefix(entry, "/") || !strings.HasSuffix(entry, "/") {
+		return "", false
+	}
+	return entry[1 : len(entry)-1], true
+}
+
+// isNamespaceName reports whether the entry is a plain namespace name
+// rather than a pattern. A namespace name cannot contain any of the
+// characters patterns are made of.
+func isNamespaceName(entry string) bool {
+	return !strings.ContainsAny(entry, `/*?[\`)
+}
+
+// listNamespaces returns the namespaces matching opts, leaving out those
+// that are terminating.
+func (r *SyncObjectReconciler) listNamespaces(ctx context.Context, opts ...client.ListOption) ([]corev1.Namespace, error) {
+	var namespaces corev1.NamespaceList
+	if err := r.Client.List(ctx, &namespaces, opts...); err != nil {
+		return nil, fmt.Errorf("failed listing namespaces: %v", err)
+	}
+
+	return slices.DeleteFunc(namespaces.Items, isTerminating), nil
 }
 
 // isTerminating reports whether the namespace is on its way out. The API
@@ -473,41 +1822,244 @@ func remove(slice []string, s string) []string {
 	})
 }
 
-// getOriginal fetches the object the reference points at.
+// getOriginals fetches the objects the reference points at: the one it
+// names, or every one its selector matches.
 //
 // A replica is refused as a source. Replicating a replica means two
 // SyncObjects managing objects of the same kind and name: they would
 // overwrite each other's copies on every pass, and because the chain keeps
 // the original's name, the second one would eventually overwrite the
-// original itself.
-func (r *SyncObjectReconciler) getOriginal(ctx context.Context, ref syncv1alpha1.Reference) (*unstructured.Unstructured, error) {
+// original itself. A selector simply passes over replicas, since it would
+// otherwise fail whenever another SyncObject replicates into the source
+// namespace.
+func getOriginals(ctx context.Context, reader client.Reader, ref syncv1alpha1.Reference) ([]*unstructured.Unstructured, error) {
+	if ref.Selector == nil {
+		original, err := getOriginal(ctx, reader, ref)
+		if err != nil {
+			return nil, err
+		}
+		return []*unstructured.Unstructured{original}, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(ref.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference selector: %v", err)
+	}
+
+	listGVK := ref.GroupVersionKind()
+	listGVK.Kind += "List"
+
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(listGVK)
+
+	if err := reader.List(ctx, &list, client.InNamespace(ref.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed listing original objects: %w", err)
+	}
+
+	var originals []*unstructured.Unstructured
+	for i := range list.Items {
+		if list.Items[i].GetLabels()[managedByLabel] == managedByValue {
+			continue
+		}
+		originals = append(originals, &list.Items[i])
+	}
+	return originals, nil
+}
+
+// getOriginal fetches the object a reference by name points at.
+func getOriginal(ctx context.Context, reader client.Reader, ref syncv1alpha1.Reference) (*unstructured.Unstructured, error) {
 	var original unstructured.Unstructured
 	original.SetGroupVersionKind(ref.GroupVersionKind())
 
-	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, &original); err != nil {
-		return nil, fmt.Errorf("failed getting original object: %v", err)
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, &original); err != nil {
+		return nil, fmt.Errorf("failed getting original object: %w", err)
 	}
 
 	if original.GetLabels()[managedByLabel] == managedByValue {
-		return nil, fmt.Errorf("refusing to replicate %s %s/%s: it is itself a replica, created by the SyncObject %q; reference that SyncObject's own source instead",
-			ref.Kind, ref.Namespace, ref.Name, original.GetAnnotations()[syncObjectAnnotation])
+		return nil, &sourceIsReplicaError{source: &original}
 	}
 
 	return &original, nil
 }
 
+// sourceIsReplicaError is a reference pointing at a replica, which is
+// refused: both SyncObjects would end up managing the same replicas.
+type sourceIsReplicaError struct {
+	source *unstructured.Unstructured
+}
+
+func (e *sourceIsReplicaError) Error() string {
+	return fmt.Sprintf("refusing to replicate %s %s/%s: it is itself a replica, created by the SyncObject %q; reference that SyncObject's own source instead",
+		e.source.GetKind(), e.source.GetNamespace(), e.source.GetName(), e.source.GetAnnotations()[syncObjectAnnotation])
+}
+
 // TODO: Add finalizer, ownerreference?
-func (r *SyncObjectReconciler) replicate(ctx context.Context, syncObject syncv1alpha1.SyncObject, original *unstructured.Unstructured, namespace string) error {
+func (r *SyncObjectReconciler) replicate(ctx context.Context, writer client.Client, syncObject syncv1alpha1.SyncObject, patches []compiledPatch, original *unstructured.Unstructured, namespace, name string) (replicaWrite, error) {
 	replica := original.DeepCopy()
 	replica.SetNamespace(namespace)
+	replica.SetName(name)
 
 	stripOriginalState(replica)
-	markAsReplica(replica, syncObject)
+	if sanitize := r.sanitizerFor(replica.GroupVersionKind().GroupKind()); sanitize != nil {
+		if err := sanitize(replica); err != nil {
+			return "", fmt.Errorf("failed sanitizing replica: %w", err)
+		}
+	}
+
+	if err := includeFields(replica, syncObject.Spec.IncludeFields); err != nil {
+		return "", fmt.Errorf("failed including fields: %w", err)
+	}
+	if err := excludeFields(replica, syncObject.Spec.ExcludeFields); err != nil {
+		return "", fmt.Errorf("failed excluding fields: %w", err)
+	}
 
-	log.Log.Info("creating/updating", "gvk", replica.GroupVersionKind().String(), "namespace", replica.GetNamespace(), "name", replica.GetName())
+	if syncObject.Spec.Keys != nil {
+		if err := selectKeys(replica, *syncObject.Spec.Keys); err != nil {
+			return "", fmt.Errorf("failed selecting keys: %w", err)
+		}
+	}
 
-	// create new replica if it doesn't already exist
-	err := r.Client.Create(ctx, replica)
+	if syncObject.Spec.RenderTemplates {
+		var target corev1.Namespace
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: namespace}, &target); err != nil {
+			return "", fmt.Errorf("failed getting namespace %q: %w", namespace, err)
+		}
+		data := templateData{
+			Name: original.GetName(),
+			Namespace: templateNamespace{
+				Name:        target.Name,
+				Labels:      target.Labels,
+				Annotations: target.Annotations,
+			},
+		}
+		if err := renderTemplates(replica, data); err != nil {
+			return "", fmt.Errorf("failed rendering replica in %q: %w", namespace, err)
+		}
+	}
+
+	markAsReplica(replica, syncObject, client.ObjectKeyFromObject(original))
+
+	if err := r.applyPatches(replica, patches, original.GetName()); err != nil {
+		return "", &patchError{replica: client.ObjectKeyFromObject(replica), err: err}
+	}
+
+	hash, err := contentHash(replica)
+	if err != nil {
+		return "", fmt.Errorf("failed hashing replica: %w", err)
+	}
+	annotations := replica.GetAnnotations()
+	annotations[contentHashAnnotation] = hash
+	replica.SetAnnotations(annotations)
+
+	current, err := r.currentReplica(ctx, replica)
+	if err != nil {
+		return "", fmt.Errorf("failed getting replica in %q: %w", namespace, err)
+	}
+	// Recreate is for a replica whose content changed; one edited by hand
+	// keeps the hash and is updated in place, and an object being adopted
+	// keeps what else it has. The replica deleted goes by what the API
+	// server has: the cache may still hold the one a pass just before
+	// recreated, and recreating that again would bring us back for
+	// another, and so on.
+	recreate := syncObject.Spec.UpdateStrategy == syncv1alpha1.RecreateUpdateStrategy &&
+		current != nil && isOwnReplica(current, syncObject) && current.GetAnnotations()[contentHashAnnotation] != hash
+	if recreate && r.cache != nil {
+		current, err = r.liveReplica(ctx, replica)
+		if err != nil {
+			return "", fmt.Errorf("failed getting replica in %q: %w", namespace, err)
+		}
+		recreate = current != nil && isOwnReplica(current, syncObject) && current.GetAnnotations()[contentHashAnnotation] != hash
+	}
+	// Something else is in the replica's place. Writing over it would take
+	// it over silently, and deleteReplicas would never clean it up.
+	overwrite := false
+	if current != nil && !isOwnReplica(current, syncObject) {
+		conflict := &conflictError{replica: client.ObjectKeyFromObject(replica), message: "an object that is not a replica exists"}
+		if current.GetLabels()[managedByLabel] == managedByValue {
+			// both would write it on every pass
+			conflict.message = fmt.Sprintf("a replica of the SyncObject %q exists", current.GetAnnotations()[syncObjectAnnotation])
+			return "", conflict
+		}
+		switch syncObject.Spec.ConflictPolicy {
+		case syncv1alpha1.AdoptConflictPolicy:
+			log.Log.Info("adopting", "gvk", replica.GroupVersionKind().String(), "namespace", replica.GetNamespace(), "name", replica.GetName())
+		case syncv1alpha1.OverwriteConflictPolicy:
+			overwrite = true
+		default:
+			return "", conflict
+		}
+	}
+
+	if upToDate(current, replica) {
+		log.Log.V(1).Info("replica up to date", "gvk", replica.GroupVersionKind().String(), "namespace", replica.GetNamespace(), "name", replica.GetName())
+		if syncObject.Spec.CopyStatus {
+			if err := r.copyStatus(ctx, writer, original, current); err != nil {
+				return "", fmt.Errorf("failed copying status to replica in %q: %w", namespace, err)
+			}
+		}
+		return replicaUnchanged, nil
+	}
+
+	// Server-side apply creates the replica, or updates only the fields we
+	// set on it before. Fields others added, like a label from a policy
+	// engine, survive; fields the source no longer has are removed.
+	applyOptions := []client.ApplyOption{client.FieldOwner(fieldManager)}
+	if syncObject.Spec.FieldConflicts != syncv1alpha1.ReportFieldConflicts {
+		applyOptions = append(applyOptions, client.ForceOwnership)
+	}
+
+	// An adopted object isn't recreated either, whatever the updateStrategy
+	// says: that would take away what else it has.
+	adopting := current != nil && !isOwnReplica(current, syncObject) && !overwrite
+
+	written := replicaApplied
+	switch {
+	case overwrite:
+		err = r.recreateReplica(ctx, writer, replica, applyOptions)
+		written = replicaOverwritten
+		if err == nil {
+			r.recordReplicaEvent(syncObject, replica, corev1.EventTypeNormal, "Overwritten", "Overwrite",
+				"Replaced %s %s/%s, which was not a replica, as the conflictPolicy is Overwrite", replica.GetKind(), replica.GetNamespace(), replica.GetName())
+		}
+	case recreate:
+		err = r.recreateReplica(ctx, writer, replica, applyOptions)
+		written = replicaRecreated
+		if err == nil {
+			r.recordReplicaEvent(syncObject, replica, corev1.EventTypeNormal, "Recreated", "Recreate",
+				"Recreated %s %s/%s, as the updateStrategy is Recreate", replica.GetKind(), replica.GetNamespace(), replica.GetName())
+		}
+	default:
+		// Written before there was a content hash, possibly by an older
+		// version of the operator.
+		if current != nil && isOwnReplica(current, syncObject) && current.GetAnnotations()[contentHashAnnotation] == "" {
+			if err := r.upgradeManagedFields(ctx, writer, replica); err != nil {
+				return "", fmt.Errorf("failed taking over the fields of the replica in %q: %w", namespace, err)
+			}
+		}
+		log.Log.Info("applying", "gvk", replica.GroupVersionKind().String(), "namespace", replica.GetNamespace(), "name", replica.GetName())
+		err = writer.Apply(ctx, client.ApplyConfigurationFromUnstructured(replica), applyOptions...)
+		if isImmutableError(err) && syncObject.Spec.UpdateStrategy == syncv1alpha1.RecreateOnImmutableErrorUpdateStrategy && !adopting {
+			reason := truncate(err.Error(), maxReferenceMessage)
+			err = r.recreateReplica(ctx, writer, replica, applyOptions)
+			written = replicaRecreated
+			if err == nil {
+				r.recordReplicaEvent(syncObject, replica, corev1.EventTypeNormal, "Recreated", "Recreate",
+					"Recreated %s %s/%s: %s", replica.GetKind(), replica.GetNamespace(), replica.GetName(), reason)
+			}
+		}
+		switch {
+		case err != nil || written != replicaApplied:
+		case current == nil:
+			r.recordReplicaEvent(syncObject, replica, corev1.EventTypeNormal, "Created", "Create",
+				"Created %s %s/%s from %s/%s", replica.GetKind(), replica.GetNamespace(), replica.GetName(), original.GetNamespace(), original.GetName())
+		case adopting:
+			r.recordReplicaEvent(syncObject, replica, corev1.EventTypeNormal, "Adopted", "Adopt",
+				"Adopted %s %s/%s, which was not a replica, as the conflictPolicy is Adopt", replica.GetKind(), replica.GetNamespace(), replica.GetName())
+		default:
+			r.recordReplicaEvent(syncObject, replica, corev1.EventTypeNormal, "Updated", "Update",
+				"Updated %s %s/%s from %s/%s", replica.GetKind(), replica.GetNamespace(), replica.GetName(), original.GetNamespace(), original.GetName())
+		}
+	}
 	if err != nil && apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause) {
 		// The namespace is being deleted, so nothing can be created in it and
 		// whatever is in there is about to go away regardless. Retrying until
@@ -517,48 +2069,658 @@ func (r *SyncObjectReconciler) replicate(ctx context.Context, syncObject syncv1a
 		// this covers the ones that started while we were working, and the
 		// ones a user listed in targetNamespaces explicitly.
 		log.Log.Info("skipping terminating namespace", "namespace", namespace)
-		return nil
+		r.recordEvent(syncObject, nil, corev1.EventTypeNormal, "Skipped", "Apply",
+			"Skipped %s %s/%s, the namespace is being deleted", replica.GetKind(), replica.GetNamespace(), replica.GetName())
+		return replicaSkipped, nil
+	}
+	if apierrors.IsConflict(err) {
+		return "", fmt.Errorf("replica in %q has fields set by other field managers, set fieldConflicts to Force to take them over: %w", namespace, err)
+	}
+	if isImmutableError(err) && adopting {
+		return "", fmt.Errorf("failed adopting the object in %q, which is not recreated as a replica: %w", namespace, err)
+	}
+	if isImmutableError(err) {
+		return "", fmt.Errorf("failed applying replica in %q, set updateStrategy to RecreateOnImmutableError to recreate it instead: %w", namespace, err)
 	}
-	if err != nil && !apierrors.IsAlreadyExists(err) {
-		// some other error than already exists...
-		return fmt.Errorf("failed creating replica in %q: %v", namespace, err)
+	if err != nil {
+		return "", fmt.Errorf("failed applying replica in %q: %w", namespace, err)
+	}
+
+	replicaWrites.WithLabelValues(replica.GetKind(), writeOperation(written, current, syncObject)).Inc()
+
+	if syncObject.Spec.CopyStatus {
+		if err := r.copyStatus(ctx, writer, original, replica); err != nil {
+			return "", fmt.Errorf("failed copying status to replica in %q: %w", namespace, err)
+		}
+	}
+
+	return written, nil
+}
+
+// replicaWrite is what replicate did with a replica.
+type replicaWrite string
+
+const (
+	// replicaUnchanged already matched its source.
+	replicaUnchanged replicaWrite = "Unchanged"
+	// replicaApplied was created or changed in place.
+	replicaApplied replicaWrite = "Applied"
+	// replicaRecreated was deleted and created anew.
+	replicaRecreated replicaWrite = "Recreated"
+	// replicaOverwritten replaced an object that wasn't a replica.
+	replicaOverwritten replicaWrite = "Overwritten"
+	// replicaSkipped is in a namespace being deleted.
+	replicaSkipped replicaWrite = "Skipped"
+)
+
+// conflictError is an object in the way of a replica, which replicate left
+// alone. sync reports these for each replica, rather than as failures.
+type conflictError struct {
+	replica client.ObjectKey
+	message string
+}
+
+func (e *conflictError) Error() string {
+	return fmt.Sprintf("conflict at replica %s: %s", e.replica, e.message)
+}
+
+// isOwnReplica reports whether obj is a replica of this SyncObject, of any
+// of its sources.
+func isOwnReplica(obj *unstructured.Unstructured, syncObject syncv1alpha1.SyncObject) bool {
+	return obj.GetLabels()[managedByLabel] == managedByValue &&
+		obj.GetAnnotations()[syncObjectAnnotation] == syncObjectID(syncObject)
+}
+
+// recreateReplica deletes the object in the replica's place and creates the
+// replica anew, for changes that can't be made in place.
+func (r *SyncObjectReconciler) recreateReplica(ctx context.Context, writer client.Client, replica *unstructured.Unstructured, applyOptions []client.ApplyOption) error {
+	log.Log.Info("recreating", "gvk", replica.GroupVersionKind().String(), "namespace", replica.GetNamespace(), "name", replica.GetName())
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(replica.GroupVersionKind())
+	existing.SetNamespace(replica.GetNamespace())
+	existing.SetName(replica.GetName())
+
+	// Background, so what belongs to the old replica goes with it, like
+	// the pods of a Job. Left to the kind's default, some would be orphaned.
+	if err := writer.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed deleting replica to recreate it: %w", err)
 	}
+
+	// A finalizer can keep the old replica around for a while. Applying
+	// now would only change the one on its way out; it is created once it
+	// is gone, which its deletion event brings us back for.
+	err := writer.Get(ctx, client.ObjectKeyFromObject(existing), existing)
 	if err == nil {
-		// we created a new replica, no need to update it now
+		return fmt.Errorf("replica is still being deleted to recreate it, waiting for its finalizers %v", existing.GetFinalizers())
+	}
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed checking the replica is gone: %w", err)
+	}
+
+	return writer.Apply(ctx, client.ApplyConfigurationFromUnstructured(replica), applyOptions...)
+}
+
+// upgradeManagedFields hands the fields an older version of the operator
+// wrote to the replica with plain updates over to fieldManager. Applying only
+// removes a field the source no longer has when no other field manager owns
+// it, so those fields would otherwise stay on the replica for good.
+func (r *SyncObjectReconciler) upgradeManagedFields(ctx context.Context, writer client.Writer, replica *unstructured.Unstructured) error {
+	// The patch replaces the managedFields as a whole, and fails on a
+	// resourceVersion that isn't the latest, so not from the cache.
+	current, err := r.liveReplica(ctx, replica)
+	if err != nil || current == nil {
+		return err
+	}
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(current, sets.New(updateFieldManager), fieldManager)
+	if err != nil || patch == nil {
+		return err
+	}
+	log.Log.Info("taking over fields written with updates", "gvk", replica.GroupVersionKind().String(), "namespace", replica.GetNamespace(), "name", replica.GetName(), "fieldManager", updateFieldManager)
+	return writer.Patch(ctx, current, client.RawPatch(types.JSONPatchType, patch))
+}
+
+// isImmutableError reports whether a write was rejected for changing a
+// field that can't be changed once set.
+func isImmutableError(err error) bool {
+	return apierrors.IsInvalid(err) && strings.Contains(err.Error(), "immutable")
+}
+
+// recordEvent records an event about the SyncObject, concerning related,
+// which may be nil. Without a Recorder nothing is recorded.
+func (r *SyncObjectReconciler) recordEvent(syncObject syncv1alpha1.SyncObject, related runtime.Object, eventType, reason, action, note string, args ...any) {
+	var regarding client.Object = &syncObject
+	if syncObject.Namespace != "" {
+		regarding = toNamespaced(syncObject)
+	}
+	r.record(regarding, related, eventType, reason, action, fmt.Sprintf(note, args...))
+}
+
+// recordReplicaEvent records an event about the SyncObject, and the same
+// about the replica, so it also shows up in the replica's namespace.
+func (r *SyncObjectReconciler) recordReplicaEvent(syncObject syncv1alpha1.SyncObject, replica client.Object, eventType, reason, action, note string, args ...any) {
+	r.recordEvent(syncObject, replica, eventType, reason, action, note, args...)
+
+	var related runtime.Object = &syncObject
+	if syncObject.Namespace != "" {
+		related = toNamespaced(syncObject)
+	}
+	r.record(replica, related, eventType, reason, action, fmt.Sprintf(note, args...))
+}
+
+// record records an event, unless the same was recorded less than
+// eventInterval ago.
+func (r *SyncObjectReconciler) record(regarding client.Object, related runtime.Object, eventType, reason, action, note string) {
+	if r.Recorder == nil {
+		return
+	}
+	key := strings.Join([]string{string(regarding.GetUID()), regarding.GetNamespace(), regarding.GetName(), reason, note}, "/")
+	if !r.events.allow(key, time.Now()) {
+		return
+	}
+	r.Recorder.Eventf(regarding, related, eventType, reason, action, "%s", note)
+}
+
+// eventInterval is how often the same event is recorded at most. A sync
+// that keeps failing fails the same way on every pass, and with hundreds of
+// namespaces the copies would crowd everything else out of the event store.
+const eventInterval = 10 * time.Minute
+
+// maxLimitedEvents is how many recent events eventLimiter remembers before
+// it forgets those older than eventInterval.
+const maxLimitedEvents = 10000
+
+// eventLimiter drops events recorded the same way less than eventInterval
+// ago. The zero value is ready to use.
+type eventLimiter struct {
+	mu     sync.Mutex
+	recent map[string]time.Time
+}
+
+// allow reports whether an event with the key may be recorded now.
+func (l *eventLimiter) allow(key string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if last, ok := l.recent[key]; ok && now.Sub(last) < eventInterval {
+		return false
+	}
+	if l.recent == nil {
+		l.recent = map[string]time.Time{}
+	}
+	if len(l.recent) >= maxLimitedEvents {
+		maps.DeleteFunc(l.recent, func(_ string, last time.Time) bool {
+			return now.Sub(last) >= eventInterval
+		})
+	}
+	l.recent[key] = now
+	return true
+}
+
+// contentHash hashes the desired state of a replica. Maps are encoded with
+// their keys sorted, so the same content always has the same hash.
+func contentHash(replica *unstructured.Unstructured) (string, error) {
+	content, err := json.Marshal(replica.Object)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// currentReplica returns the replica as last observed, or nil when there is
+// none yet. It is read from the cache, which the watch on the replicas of
+// the reference's kind fills anyway, rather than costing a request per
+// replica. Until that watch is started, or when it failed to, it is asked
+// for instead.
+func (r *SyncObjectReconciler) currentReplica(ctx context.Context, replica *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if r.cache == nil {
+		return r.liveReplica(ctx, replica)
+	}
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(replica.GroupVersionKind())
+	err := r.cache.Get(ctx, client.ObjectKeyFromObject(replica), current)
+	// The cache only holds replicas. Whatever else may be in the way of
+	// one is only found by asking, which is cheap next to the write that
+	// follows when there is nothing.
+	var notCached *cache.ErrResourceNotCached
+	if apierrors.IsNotFound(err) || errors.As(err, &notCached) {
+		return r.liveReplica(ctx, replica)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// liveReplica is currentReplica asking the API server rather than the
+// cache, which may not have caught up with a write just made.
+func (r *SyncObjectReconciler) liveReplica(ctx context.Context, replica *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(replica.GroupVersionKind())
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(replica), current)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// upToDate reports whether applying desired to current would change
+// nothing, so the write can be skipped. It takes both checks:
+//   - the hash catches a field removed from the source, which current
+//     still having doesn't show;
+//   - comparing the fields catches a replica edited since, which keeps the
+//     hash of what was applied before.
+func upToDate(current, desired *unstructured.Unstructured) bool {
+	if current == nil || current.GetDeletionTimestamp() != nil {
+		return false
+	}
+	if current.GetAnnotations()[contentHashAnnotation] != desired.GetAnnotations()[contentHashAnnotation] {
+		return false
+	}
+	return containsFields(current.Object, desired.Object)
+}
+
+// copyStatus applies the status of the original to the replica, which holds
+// what the API server returned for its last write. It writes nothing when
+// the replica already has that status, or the original has none.
+func (r *SyncObjectReconciler) copyStatus(ctx context.Context, writer client.StatusClient, original, replica *unstructured.Unstructured) error {
+	status, ok := original.Object["status"]
+	if !ok || equality.Semantic.DeepEqual(replica.Object["status"], status) {
 		return nil
 	}
 
-	// replica already exists, just update it
-	if err := r.Client.Update(ctx, replica); err != nil {
-		return fmt.Errorf("failed updating replica in %q: %v", namespace, err)
+	// only what identifies the replica, and the status
+	applied := &unstructured.Unstructured{Object: map[string]any{"status": runtime.DeepCopyJSONValue(status)}}
+	applied.SetGroupVersionKind(replica.GroupVersionKind())
+	applied.SetNamespace(replica.GetNamespace())
+	applied.SetName(replica.GetName())
+
+	err := writer.Status().Apply(ctx, client.ApplyConfigurationFromUnstructured(applied), client.FieldOwner(fieldManager), client.ForceOwnership)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("%s has no status subresource, or the replica is gone: %w", replica.GetKind(), err)
+	}
+	return err
+}
+
+// replicaKey identifies a replica across kinds.
+type replicaKey struct {
+	schema.GroupKind
+	client.ObjectKey
+}
+
+// replicaNamer returns the name of the replica of the source named
+// sourceName in namespace.
+type replicaNamer func(sourceName, namespace string) (string, error)
+
+// keyFields are the fields selectKeys picks the keys of.
+var keyFields = []string{"data", "binaryData", "stringData"}
+
+// selectKeys drops the keys of data, binaryData and stringData that the
+// selection doesn't include or does exclude, and renames those it says to.
+// Objects without these fields are left alone.
+func selectKeys(replica *unstructured.Unstructured, selection syncv1alpha1.KeySelection) error {
+	for _, field := range keyFields {
+		values, ok := replica.Object[field].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		selected := make(map[string]any, len(values))
+		for key, value := range values {
+			included := len(selection.Include) == 0
+			if !included {
+				var err error
+				if included, err = matchesKey(selection.Include, key); err != nil {
+					return err
+				}
+			}
+			excluded, err := matchesKey(selection.Exclude, key)
+			if err != nil {
+				return err
+			}
+			if !included || excluded {
+				continue
+			}
+
+			name := key
+			if renamed, ok := selection.Rename[key]; ok {
+				name = renamed
+			}
+			if _, taken := selected[name]; taken {
+				return fmt.Errorf("more than one key of %s would be called %q", field, name)
+			}
+			selected[name] = value
+		}
+		replica.Object[field] = selected
+	}
+	return nil
+}
+
+// matchesKey reports whether key matches any of the globs.
+func matchesKey(globs []string, key string) (bool, error) {
+	for _, glob := range globs {
+		matched, err := path.Match(glob, key)
+		if err != nil {
+			return false, fmt.Errorf("invalid key pattern %q: %v", glob, err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// templateData is what a targetName, and with renderTemplates the content
+// of a replica, is rendered with.
+type templateData struct {
+	// Name is the name of the source.
+	Name      string
+	Namespace templateNamespace
+}
+
+// templateNamespace describes the target namespace to a template. Only its
+// name is known when rendering a targetName, which is needed before the
+// namespaces are looked at.
+type templateNamespace struct {
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// renderTemplates renders every string in the replica as a Go template,
+// apart from those identifying it: its apiVersion, kind and metadata. Of the
+// metadata, only the values of the labels and annotations are rendered.
+//
+// Templates get nothing that changes from one call to the next -- no time,
+// no randomness -- and ranging over a map goes by its sorted keys, so the
+// same source and namespace always render the same replica. See
+// markAsReplica for why that matters.
+func renderTemplates(replica *unstructured.Unstructured, data templateData) error {
+	for key, value := range replica.Object {
+		switch key {
+		case "apiVersion", "kind":
+			continue
+		case "metadata":
+			metadata, _ := value.(map[string]any)
+			for _, field := range []string{"labels", "annotations"} {
+				if _, err := renderValue(metadata[field], data, "metadata."+field); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		rendered, err := renderValue(value, data, key)
+		if err != nil {
+			return err
+		}
+		replica.Object[key] = rendered
+	}
+	return nil
+}
+
+// renderValue renders the strings in value, which is found at path. Maps
+// and slices are rendered in place.
+func renderValue(value any, data templateData, path string) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.Contains(v, "{{") {
+			return v, nil
+		}
+		tmpl, err := template.New(path).Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template in %s: %v", path, err)
+		}
+		var rendered strings.Builder
+		if err := tmpl.Execute(&rendered, data); err != nil {
+			return nil, fmt.Errorf("failed rendering %s: %v", path, err)
+		}
+		return rendered.String(), nil
+	case map[string]any:
+		for key, item := range v {
+			rendered, err := renderValue(item, data, path+"."+key)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = rendered
+		}
+		return v, nil
+	case []any:
+		for i, item := range v {
+			rendered, err := renderValue(item, data, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+// newReplicaNamer returns the replicaNamer for a targetName. Replicas keep
+// the name of their source when targetName is empty.
+//
+// The template is tried out once here, so a mistake in it -- a field that
+// doesn't exist, say -- fails the whole sync up front rather than every
+// replica on its own.
+func newReplicaNamer(targetName string) (replicaNamer, error) {
+	if targetName == "" {
+		return func(sourceName, _ string) (string, error) { return sourceName, nil }, nil
+	}
+
+	tmpl, err := template.New("targetName").Option("missingkey=error").Parse(targetName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid targetName: %v", err)
+	}
+
+	name := func(sourceName, namespace string) (string, error) {
+		var rendered strings.Builder
+		data := templateData{Name: sourceName, Namespace: templateNamespace{Name: namespace}}
+		if err := tmpl.Execute(&rendered, data); err != nil {
+			return "", fmt.Errorf("failed rendering targetName: %v", err)
+		}
+		if rendered.Len() == 0 {
+			return "", errors.New("targetName rendered to an empty name")
+		}
+		return rendered.String(), nil
+	}
+
+	if _, err := name("name", "namespace"); err != nil {
+		return nil, fmt.Errorf("invalid targetName: %v", err)
+	}
+	return name, nil
+}
+
+// patchError is the patches of a replica failing to apply. sync reports
+// these for each replica.
+type patchError struct {
+	replica client.ObjectKey
+	err     error
+}
+
+func (e *patchError) Error() string {
+	return fmt.Sprintf("failed patching replica %s: %v", e.replica, e.err)
+}
+
+func (e *patchError) Unwrap() error {
+	return e.err
+}
+
+// applyPatches applies the patches meant for the replica of the source
+// named sourceName, in order.
+//
+// A patch is refused when it changes what identifies the replica: its
+// name, namespace or kind would have it written somewhere else, and without
+// its marks it would never be cleaned up.
+func (r *SyncObjectReconciler) applyPatches(replica *unstructured.Unstructured, patches []compiledPatch, sourceName string) error {
+	for i, patch := range patches {
+		applies, err := patchApplies(patch, replica, sourceName)
+		if err != nil {
+			return fmt.Errorf("patch %d: %v", i, err)
+		}
+		if !applies {
+			continue
+		}
+
+		current, err := replica.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("patch %d: %v", i, err)
+		}
+		patched, err := r.patch(replica.GroupVersionKind(), patch.Patch, current)
+		if err != nil {
+			return fmt.Errorf("patch %d: %v", i, err)
+		}
+
+		var result unstructured.Unstructured
+		if err := result.UnmarshalJSON(patched); err != nil {
+			return fmt.Errorf("patch %d: %v", i, err)
+		}
+		if err := checkPatched(replica, &result); err != nil {
+			return fmt.Errorf("patch %d: %v", i, err)
+		}
+		replica.Object = result.Object
+	}
+	return nil
+}
+
+// compiledPatch is a patch with its namespaces compiled, so they aren't
+// compiled again for every replica they are matched against.
+type compiledPatch struct {
+	syncv1alpha1.Patch
+	namespaces namespacePatterns
+	// err is why the namespaces didn't compile, reported for each replica
+	// the patch could be meant for
+	err error
+}
+
+// compilePatches compiles the namespaces of the patches.
+func compilePatches(patches []syncv1alpha1.Patch) []compiledPatch {
+	compiled := make([]compiledPatch, 0, len(patches))
+	for _, patch := range patches {
+		namespaces, err := compileNamespacePatterns(patch.Namespaces)
+		compiled = append(compiled, compiledPatch{Patch: patch, namespaces: namespaces, err: err})
+	}
+	return compiled
+}
+
+// patchApplies reports whether the patch is meant for the replica.
+func patchApplies(patch compiledPatch, replica *unstructured.Unstructured, sourceName string) (bool, error) {
+	if patch.Kind != "" && patch.Kind != replica.GetKind() {
+		return false, nil
+	}
+	if patch.Name != "" && patch.Name != sourceName {
+		return false, nil
+	}
+	if len(patch.Namespaces) == 0 {
+		return true, nil
+	}
+	if patch.err != nil {
+		return false, patch.err
+	}
+	return patch.namespaces.matches(replica.GetNamespace()), nil
+}
+
+// patch applies a single patch to the JSON of an object of kind gvk.
+func (r *SyncObjectReconciler) patch(gvk schema.GroupVersionKind, patch syncv1alpha1.Patch, current []byte) ([]byte, error) {
+	if len(patch.Patch.Raw) == 0 {
+		return nil, errors.New("the patch is empty")
+	}
+
+	switch patch.Type {
+	case syncv1alpha1.JSONPatchType:
+		operations, err := jsonpatch.DecodePatch(patch.Patch.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON patch: %v", err)
+		}
+		return operations.Apply(current)
+	case syncv1alpha1.MergePatchType, "":
+		return jsonpatch.MergePatch(current, patch.Patch.Raw)
+	case syncv1alpha1.StrategicMergePatchType:
+		// The merge strategy of each field comes from the Go type, which
+		// only the kinds built into Kubernetes have.
+		dataStruct, err := r.Client.Scheme().New(gvk)
+		if runtime.IsNotRegisteredError(err) {
+			return nil, fmt.Errorf("a strategic merge patch only works for kinds built into Kubernetes, use a Merge patch for %s", gvk.Kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return strategicpatch.StrategicMergePatch(current, patch.Patch.Raw, dataStruct)
+	default:
+		return nil, fmt.Errorf("unknown patch type %q", patch.Type)
+	}
+}
+
+// checkPatched refuses a patch that changed what identifies the replica.
+func checkPatched(replica, patched *unstructured.Unstructured) error {
+	if patched.GroupVersionKind() != replica.GroupVersionKind() ||
+		patched.GetNamespace() != replica.GetNamespace() ||
+		patched.GetName() != replica.GetName() {
+		return errors.New("a patch must not change the name, namespace or kind of the replica")
 	}
 
+	if patched.GetLabels()[managedByLabel] != managedByValue {
+		return fmt.Errorf("a patch must not remove the label %s", managedByLabel)
+	}
+	for _, key := range []string{syncObjectAnnotation, sourceNamespaceAnnotation, sourceNameAnnotation} {
+		if patched.GetAnnotations()[key] != replica.GetAnnotations()[key] {
+			return fmt.Errorf("a patch must not change the annotation %s", key)
+		}
+	}
 	return nil
 }
 
 // isReplicaOf reports whether obj is a replica this SyncObject created from
-// ref, going by the marks replicate put on it.
+// ref, going by the marks replicate put on it. For a reference with a
+// selector, that is a replica of any object in the reference's namespace.
 func isReplicaOf(obj *unstructured.Unstructured, syncObject syncv1alpha1.SyncObject, ref syncv1alpha1.Reference) bool {
 	if obj.GetLabels()[managedByLabel] != managedByValue {
 		return false
 	}
 
 	annotations := obj.GetAnnotations()
-	return annotations[syncObjectAnnotation] == syncObject.Name &&
+	return annotations[syncObjectAnnotation] == syncObjectID(syncObject) &&
 		annotations[sourceNamespaceAnnotation] == ref.Namespace &&
-		annotations[sourceNameAnnotation] == ref.Name
+		(ref.Selector != nil || annotations[sourceNameAnnotation] == ref.Name)
+}
+
+// keepFunc tells deleteReplicas which replicas to leave alone, given a
+// replica and the object it was copied from.
+type keepFunc func(replica, source client.ObjectKey) bool
+
+// sourceOf returns the object a replica was copied from, going by its marks.
+func sourceOf(replica client.Object) client.ObjectKey {
+	return client.ObjectKey{
+		Namespace: replica.GetAnnotations()[sourceNamespaceAnnotation],
+		Name:      replica.GetAnnotations()[sourceNameAnnotation],
+	}
 }
 
 // deleteReplicas removes the replicas this SyncObject created from ref,
-// apart from those in the keep namespaces. Pass no keep namespaces to
-// remove all of them.
+// apart from those keep asks to leave alone. Pass a nil keep to remove all
+// of them.
+//
+// They are found with the operator's own client, which only reads the
+// marks, and deleted with writer.
+//
+// Anyone allowed to patch an object can put the marks on it, and writer is
+// the operator's own client for a NamespacedSyncObject whose ServiceAccount
+// is gone. A tenant's replicas are therefore only deleted where it could
+// have written them, so it can't get the operator to delete an object it
+// was only allowed to label.
 //
 // Replicas are identified by the marks replicate leaves on them rather than
 // by name, so an unrelated object that merely happens to share a name is
 // never touched. Neither is the original, which carries no such marks --
 // this is what makes cleaning up a previous reference safe even when it
 // shares a kind and name with the current one.
-func (r *SyncObjectReconciler) deleteReplicas(ctx context.Context, syncObject syncv1alpha1.SyncObject, ref syncv1alpha1.Reference, keep []string) error {
+func (r *SyncObjectReconciler) deleteReplicas(ctx context.Context, writer client.Writer, syncObject syncv1alpha1.SyncObject, ref syncv1alpha1.Reference, keep keepFunc) error {
 	listGVK := ref.GroupVersionKind()
 	listGVK.Kind += "List"
 
@@ -584,42 +2746,97 @@ func (r *SyncObjectReconciler) deleteReplicas(ctx context.Context, syncObject sy
 		if !isReplicaOf(&replica, syncObject, ref) {
 			continue
 		}
-		if slices.Contains(keep, replica.GetNamespace()) {
+		if keep != nil && keep(client.ObjectKeyFromObject(&replica), sourceOf(&replica)) {
 			continue
 		}
+		if syncObject.Namespace != "" {
+			allowed, err := r.tenantTargetNamespaces(ctx, syncObject, ref, []string{replica.GetNamespace()})
+			if err != nil {
+				multiErr = errors.Join(multiErr, err)
+				continue
+			}
+			if len(allowed) == 0 {
+				continue
+			}
+		}
 
 		log.Log.Info("deleting replica", "gvk", replica.GroupVersionKind().String(), "namespace", replica.GetNamespace(), "name", replica.GetName())
 
-		if err := r.Client.Delete(ctx, &replica); err != nil && !apierrors.IsNotFound(err) {
-			multiErr = errors.Join(multiErr, fmt.Errorf("failed deleting replica in %q: %v", replica.GetNamespace(), err))
+		if err := writer.Delete(ctx, &replica); err != nil {
+			if !apierrors.IsNotFound(err) {
+				multiErr = errors.Join(multiErr, fmt.Errorf("failed deleting replica in %q: %w", replica.GetNamespace(), err))
+			}
+			continue
 		}
+		replicaDeletions.WithLabelValues(replica.GetKind()).Inc()
+		r.recordReplicaEvent(syncObject, &replica, corev1.EventTypeNormal, "Deleted", "Delete",
+			"Deleted %s %s/%s", replica.GetKind(), replica.GetNamespace(), replica.GetName())
 	}
 
 	return multiErr
 }
 
 // referencesToCleanUp returns every reference whose replicas belong to this
-// SyncObject: the current one, plus the previously applied one when
-// spec.reference has changed and its replicas haven't been cleaned up yet.
+// SyncObject: the current ones, plus the stale ones.
 func referencesToCleanUp(syncObject syncv1alpha1.SyncObject) []syncv1alpha1.Reference {
-	refs := []syncv1alpha1.Reference{syncObject.Spec.Reference}
-	if applied := syncObject.Status.AppliedReference; applied != nil && *applied != syncObject.Spec.Reference {
-		refs = append(refs, *applied)
+	return append(syncObject.Spec.AllReferences(), staleReferences(syncObject)...)
+}
+
+// staleReferences returns the previously applied references that are no
+// longer in the spec, and whose replicas haven't been cleaned up yet.
+func staleReferences(syncObject syncv1alpha1.SyncObject) []syncv1alpha1.Reference {
+	current := syncObject.Spec.AllReferences()
+
+	var stale []syncv1alpha1.Reference
+	for _, applied := range appliedReferences(syncObject.Status) {
+		if !containsReference(current, applied) {
+			stale = append(stale, applied)
+		}
+	}
+	return stale
+}
+
+// appliedReferences returns the references recorded as applied, including
+// the one an older version recorded in status.appliedReference.
+func appliedReferences(status syncv1alpha1.SyncObjectStatus) []syncv1alpha1.Reference {
+	applied := slices.Clone(status.AppliedReferences)
+	if status.AppliedReference != nil && !containsReference(applied, *status.AppliedReference) {
+		applied = append(applied, *status.AppliedReference)
 	}
-	return refs
+	return applied
+}
+
+// containsReference reports whether ref is one of refs. References hold a
+// pointer, so they can't be compared with ==.
+func containsReference(refs []syncv1alpha1.Reference, ref syncv1alpha1.Reference) bool {
+	return slices.ContainsFunc(refs, func(r syncv1alpha1.Reference) bool {
+		return equality.Semantic.DeepEqual(r, ref)
+	})
 }
 
 // maxConditionMessage keeps the message inside the 32768 characters the API
 // allows. An error joined across a few hundred namespaces can get long.
 const maxConditionMessage = 2048
 
+// maxReferenceMessage is the same for the message of each reference, kept
+// shorter since there can be up to a hundred of them.
+const maxReferenceMessage = 512
+
+// maxPatchFailures is how many patch failures are reported across all
+// references, like maxReplicaStatuses. The CRD limits each reference the
+// same.
+const maxPatchFailures = 100
+
+// maxConflicts is maxPatchFailures for the conflicts.
+const maxConflicts = 100
+
 // updateStatus records the outcome of a sync on the SyncObject itself, so a
 // failure is visible to whoever created it rather than only in the
 // operator's logs.
 //
 // It writes nothing when the status is unchanged. A write here would
 // otherwise wake the SyncObject watch and reconcile again, forever.
-func (r *SyncObjectReconciler) updateStatus(ctx context.Context, syncObject *syncv1alpha1.SyncObject, syncErr error) error {
+func (r *SyncObjectReconciler) updateStatus(ctx context.Context, syncObject *syncv1alpha1.SyncObject, results []syncv1alpha1.ReferenceStatus, syncErr error) error {
 	previous := syncObject.Status.DeepCopy()
 
 	condition := metav1.Condition{
@@ -629,32 +2846,145 @@ func (r *SyncObjectReconciler) updateStatus(ctx context.Context, syncObject *syn
 		Message:            "The reference is replicated to its target namespaces",
 		ObservedGeneration: syncObject.Generation,
 	}
+	applied := syncObject.Spec.AllReferences()
 	if syncErr != nil {
 		condition.Status = metav1.ConditionFalse
 		condition.Reason = "SyncFailed"
+		if apierrors.IsForbidden(syncErr) {
+			// told apart, as it's fixed by granting permissions rather
+			// than by fixing the spec
+			condition.Reason = "Forbidden"
+		}
 		condition.Message = truncate(syncErr.Error(), maxConditionMessage)
-	} else {
-		// Only recorded once the replicas actually exist. If replication
-		// failed we keep the previous value, so the next attempt still knows
-		// which replicas to clean up.
-		ref := syncObject.Spec.Reference
-		syncObject.Status.AppliedReference = &ref
+
+		// The stale references are only forgotten once the replicas
+		// actually are gone. Until then we keep them, so the next attempt
+		// still knows which replicas to clean up.
+		applied = appliedReferences(syncObject.Status)
+		for _, ref := range syncObject.Spec.AllReferences() {
+			if !containsReference(applied, ref) {
+				applied = append(applied, ref)
+			}
+		}
 	}
+	syncObject.Status.AppliedReferences = applied
+	syncObject.Status.AppliedReference = nil
+	syncObject.Status.DesiredReplicas, syncObject.Status.SyncedReplicas, syncObject.Status.FailedReplicas = 0, 0, 0
+	for i := range results {
+		results[i].Recreations = keepRecreations(results[i].Recreations, previous.References, results[i].Reference, len(results))
+		keepReplicaSyncTimes(results[i].Replicas, previous.References, results[i].Reference)
+
+		syncObject.Status.DesiredReplicas += results[i].DesiredReplicas
+		syncObject.Status.SyncedReplicas += results[i].SyncedReplicas
+		syncObject.Status.FailedReplicas += results[i].FailedReplicas
+		for _, replica := range results[i].Replicas {
+			if replica.LastSyncTime != nil && (syncObject.Status.LastSyncTime == nil || syncObject.Status.LastSyncTime.Before(replica.LastSyncTime)) {
+				syncObject.Status.LastSyncTime = replica.LastSyncTime.DeepCopy()
+			}
+		}
+	}
+	syncObject.Status.References = results
+	syncObject.Status.ReferenceCount = int32(len(results))
 
 	meta.SetStatusCondition(&syncObject.Status.Conditions, condition)
 	syncObject.Status.ObservedGeneration = syncObject.Generation
 
+	reportReplicas(*syncObject, syncErr == nil)
+
 	if equality.Semantic.DeepEqual(previous, &syncObject.Status) {
 		return nil
 	}
 
-	if err := r.Status().Update(ctx, syncObject); err != nil {
+	if err := r.writeSyncObject(ctx, syncObject, true); err != nil {
 		return fmt.Errorf("failed updating status: %v", err)
 	}
 
 	return nil
 }
 
+// maxRecreations is maxPatchFailures for the recreations.
+const maxRecreations = 100
+
+// maxReferenceRecreations is how many recreations are kept per reference,
+// which the CRD limits the same.
+const maxReferenceRecreations = 10
+
+// keepRecreations adds the recreations of ref recorded before to those of
+// the last sync, which come first, up to ref's share of maxRecreations
+// among the given number of references.
+func keepRecreations(recreations []syncv1alpha1.Recreation, previous []syncv1alpha1.ReferenceStatus, ref syncv1alpha1.Reference, references int) []syncv1alpha1.Recreation {
+	for _, status := range previous {
+		if equality.Semantic.DeepEqual(status.Reference, ref) {
+			recreations = append(recreations, status.Recreations...)
+			break
+		}
+	}
+	if limit := min(maxReferenceRecreations, maxRecreations/references); len(recreations) > limit {
+		recreations = recreations[:limit]
+	}
+	return recreations
+}
+
+// maxReplicaStatuses is how many replicas are listed in the status, across
+// all references, which the CRD limits the same. Only with the patch
+// failures, conflicts and recreations limited across references too does
+// the status stay within the size limit of an object, even with every field
+// at its longest.
+const maxReplicaStatuses = 500
+
+// maxReplicaMessage is maxReferenceMessage for the message of each replica,
+// kept shorter still since there can be many more of them.
+const maxReplicaMessage = 256
+
+// limitReplicaStatuses sorts the replicas that didn't sync first, then by
+// namespace and name, and keeps the first max of them. It returns how many
+// were left out.
+func limitReplicaStatuses(replicas []syncv1alpha1.ReplicaStatus, max int) ([]syncv1alpha1.ReplicaStatus, int32) {
+	slices.SortStableFunc(replicas, func(a, b syncv1alpha1.ReplicaStatus) int {
+		if aSynced, bSynced := a.State == syncv1alpha1.SyncedReplicaState, b.State == syncv1alpha1.SyncedReplicaState; aSynced != bSynced {
+			if aSynced {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+	if len(replicas) <= max {
+		return replicas, 0
+	}
+	return replicas[:max], int32(len(replicas) - max)
+}
+
+// keepReplicaSyncTimes fills in the lastSyncTime of the replicas that
+// weren't written in the last sync from the status recorded before. One
+// that is synced but wasn't listed before gets the current time.
+//
+// Only a write moves the time on. Were it the time of every sync, the
+// status would change on each of them, and its update trigger yet another.
+func keepReplicaSyncTimes(replicas []syncv1alpha1.ReplicaStatus, previous []syncv1alpha1.ReferenceStatus, ref syncv1alpha1.Reference) {
+	previousTimes := map[client.ObjectKey]*metav1.Time{}
+	for _, status := range previous {
+		if equality.Semantic.DeepEqual(status.Reference, ref) {
+			for _, replica := range status.Replicas {
+				previousTimes[client.ObjectKey{Namespace: replica.Namespace, Name: replica.Name}] = replica.LastSyncTime
+			}
+			break
+		}
+	}
+
+	for i := range replicas {
+		if replicas[i].LastSyncTime != nil {
+			continue
+		}
+		if previousTime, ok := previousTimes[client.ObjectKey{Namespace: replicas[i].Namespace, Name: replicas[i].Name}]; ok {
+			replicas[i].LastSyncTime = previousTime
+		} else if replicas[i].State == syncv1alpha1.SyncedReplicaState {
+			now := metav1.Now()
+			replicas[i].LastSyncTime = &now
+		}
+	}
+}
+
 func truncate(s string, max int) string {
 	if len(s) <= max {
 		return s
@@ -3,22 +3,34 @@ package controllers
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
 	syncv1alpha1 "github.com/sj14/sync-operator/api/v1alpha1"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 func TestRemove(t *testing.T) {
@@ -103,7 +115,7 @@ func TestIndexByReference(t *testing.T) {
 		Namespace: "my-ns",
 	}
 	syncObject := &syncv1alpha1.SyncObject{
-		Spec: syncv1alpha1.SyncObjectSpec{Reference: ref},
+		Spec: syncv1alpha1.SyncObjectSpec{Reference: &ref},
 	}
 
 	want := []string{referencedObjectKey(ref.GroupVersionKind(), ref.Name)}
@@ -118,6 +130,15 @@ func TestIndexByReference(t *testing.T) {
 	replica.SetName(ref.Name)
 	require.Equal(t, want[0], referencedObjectKey(replica.GroupVersionKind(), replica.GetName()))
 
+	// A selector may match any object of the kind, so it's indexed under a
+	// key covering the whole kind.
+	selectorRef := ref
+	selectorRef.Name = ""
+	selectorRef.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"shared": "true"}}
+	require.Equal(t, []string{referencedKindKey(ref.GroupVersionKind())},
+		indexByReference(&syncv1alpha1.SyncObject{Spec: syncv1alpha1.SyncObjectSpec{Reference: &selectorRef}}))
+	require.NotEqual(t, referencedKindKey(ref.GroupVersionKind()), want[0])
+
 	// indexByReference is registered against the SyncObject type; anything
 	// else should be ignored rather than panic.
 	require.Nil(t, indexByReference(&corev1.ConfigMap{}))
@@ -129,6 +150,7 @@ func TestGetTargetNamespaces(t *testing.T) {
 	tests := []struct {
 		name             string
 		targetNamespaces []string
+		targetSelector   *metav1.LabelSelector
 		ignoreNamespaces []string
 		wantTargets      []string
 	}{
@@ -158,15 +180,75 @@ func TestGetTargetNamespaces(t *testing.T) {
 			targetNamespaces: []string{"a-ns", referenceNamespace},
 			wantTargets:      []string{"a-ns"},
 		},
+		{
+			name:           "a selector only picks the matching namespaces",
+			targetSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			wantTargets:    []string{"a-ns"},
+		},
+		{
+			name:             "a selector adds to the explicit targets",
+			targetNamespaces: []string{"b-ns"},
+			targetSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			wantTargets:      []string{"a-ns", "b-ns"},
+		},
+		{
+			name:             "a namespace both listed and selected is a target once",
+			targetNamespaces: []string{"a-ns"},
+			targetSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			wantTargets:      []string{"a-ns"},
+		},
+		{
+			name:           "a selector matching nothing means no targets rather than all",
+			targetSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "nobody"}},
+			wantTargets:    nil,
+		},
+		{
+			name:             "ignored namespaces are dropped from the selected ones",
+			targetSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			ignoreNamespaces: []string{"a-ns"},
+			wantTargets:      nil,
+		},
+		{
+			// the reference namespace carries the label too
+			name:           "a selector matching the reference namespace does not replicate over the original",
+			targetSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			wantTargets:    []string{"b-ns"},
+		},
+		{
+			name:             "a glob target picks the matching namespaces",
+			targetNamespaces: []string{"*-ns"},
+			wantTargets:      []string{"a-ns", "b-ns"},
+		},
+		{
+			name:             "a regular expression target picks the matching namespaces",
+			targetNamespaces: []string{"/[ab]-ns/"},
+			wantTargets:      []string{"a-ns", "b-ns"},
+		},
+		{
+			name:             "a regular expression has to match the whole name",
+			targetNamespaces: []string{"/a/"},
+			wantTargets:      nil,
+		},
+		{
+			name:             "a glob ignore drops the matching namespaces",
+			ignoreNamespaces: []string{"a-*"},
+			wantTargets:      []string{"b-ns"},
+		},
+		{
+			name:             "a regular expression ignore drops the matching namespaces",
+			targetNamespaces: []string{"*-ns"},
+			ignoreNamespaces: []string{"/b-.+/"},
+			wantTargets:      []string{"a-ns"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeClient := fake.NewClientBuilder().
 				WithObjects(
-					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: referenceNamespace}},
-					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a-ns"}},
-					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "b-ns"}},
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: referenceNamespace, Labels: map[string]string{"env": "prod"}}},
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a-ns", Labels: map[string]string{"team": "payments"}}},
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "b-ns", Labels: map[string]string{"env": "prod"}}},
 				).
 				Build()
 
@@ -174,19 +256,22 @@ func TestGetTargetNamespaces(t *testing.T) {
 
 			syncObject := syncv1alpha1.SyncObject{
 				Spec: syncv1alpha1.SyncObjectSpec{
-					Reference: syncv1alpha1.Reference{
+					Reference: &syncv1alpha1.Reference{
 						Group:     "",
 						Version:   "v1",
 						Kind:      "ConfigMap",
 						Name:      "cm",
 						Namespace: referenceNamespace,
 					},
-					TargetNamespaces: tt.targetNamespaces,
-					IgnoreNamespaces: tt.ignoreNamespaces,
+					TargetNamespaces:        tt.targetNamespaces,
+					TargetNamespaceSelector: tt.targetSelector,
+					IgnoreNamespaces:        tt.ignoreNamespaces,
 				},
 			}
 
-			targets, err := r.getTargetNamespaces(context.Background(), syncObject)
+			patterns, err := checkNamespacePatterns(syncObject.Spec)
+			require.NoError(t, err)
+			targets, err := r.getTargetNamespaces(context.Background(), syncObject, patterns, *syncObject.Spec.Reference)
 			require.NoError(t, err)
 
 			require.ElementsMatch(t, tt.wantTargets, targets)
@@ -198,6 +283,89 @@ func TestGetTargetNamespaces(t *testing.T) {
 	}
 }
 
+func TestMatchesNamespace(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   []string
+		namespace string
+		want      bool
+	}{
+		{"plain name matches itself", []string{"team-a"}, "team-a", true},
+		{"plain name matches nothing else", []string{"team-a"}, "team-ab", false},
+		{"glob", []string{"ci-*"}, "ci-1234", true},
+		{"glob not matching", []string{"ci-*"}, "prod-ci-1234", false},
+		{"glob character class", []string{"team-[ab]"}, "team-b", true},
+		{"regular expression", []string{"/tenant-[a-z]+-prod/"}, "tenant-acme-prod", true},
+		{"regular expression is anchored at the start", []string{"/tenant-[a-z]+-prod/"}, "old-tenant-acme-prod", false},
+		{"regular expression is anchored at the end", []string{"/tenant-[a-z]+-prod/"}, "tenant-acme-prod-2", false},
+		{"regular expression alternation is anchored as a whole", []string{"/a|b/"}, "ab", false},
+		{"any entry matching is enough", []string{"nope", "ci-*"}, "ci-1", true},
+		{"no entries match nothing", nil, "anything", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patterns, err := compileNamespacePatterns(tt.entries)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, patterns.matches(tt.namespace))
+		})
+	}
+
+	t.Run("an invalid glob is an error", func(t *testing.T) {
+		_, err := compileNamespacePatterns([]string{"team-["})
+		require.ErrorContains(t, err, "team-[")
+	})
+
+	t.Run("an invalid regular expression is an error", func(t *testing.T) {
+		_, err := compileNamespacePatterns([]string{"/team-(/"})
+		require.ErrorContains(t, err, "/team-(/")
+	})
+}
+
+func TestCheckNamespacePatterns(t *testing.T) {
+	tests := []struct {
+		name        string
+		targets     []string
+		ignores     []string
+		wantMessage string
+	}{
+		{name: "plain names", targets: []string{"a"}, ignores: []string{"b"}},
+		{name: "overlapping patterns are not a contradiction", targets: []string{"ci-*"}, ignores: []string{"ci-*-keep"}},
+		{name: "invalid target pattern", targets: []string{"/(/"}, wantMessage: "invalid namespace pattern"},
+		{name: "invalid ignore pattern", ignores: []string{"["}, wantMessage: "invalid namespace pattern"},
+		{
+			name:        "a listed target excluded by an ignore glob",
+			targets:     []string{"ci-1"},
+			ignores:     []string{"ci-*"},
+			wantMessage: `targetNamespaces entry "ci-1" is excluded by ignoreNamespaces entry "ci-*"`,
+		},
+		{
+			name:        "a listed target excluded by an ignore regular expression",
+			targets:     []string{"tenant-acme-prod"},
+			ignores:     []string{"/tenant-.*/"},
+			wantMessage: `targetNamespaces entry "tenant-acme-prod" is excluded`,
+		},
+		{name: "ignoring every namespace with a glob", ignores: []string{"**"}, wantMessage: `ignoreNamespaces entry "**" would exclude every namespace`},
+		{name: "ignoring every namespace with a regular expression", ignores: []string{"dev", "/.*/"}, wantMessage: `ignoreNamespaces entry "/.*/" would exclude every namespace`},
+		{name: "ignoring every namespace with a character class", ignores: []string{"/[a-z0-9-]+/"}, wantMessage: "would exclude every namespace"},
+		{name: "ignoring all but some namespaces", ignores: []string{"/[^x]*/", "?*-*"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := checkNamespacePatterns(syncv1alpha1.SyncObjectSpec{
+				TargetNamespaces: tt.targets,
+				IgnoreNamespaces: tt.ignores,
+			})
+			if tt.wantMessage == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantMessage)
+		})
+	}
+}
+
 func TestStripOriginalState(t *testing.T) {
 	creationTimestamp := metav1.Now()
 
@@ -209,6 +377,11 @@ func TestStripOriginalState(t *testing.T) {
 	original.SetUID("2b1c4d8e-0000-0000-0000-000000000000")
 	original.SetCreationTimestamp(creationTimestamp)
 	original.SetGeneration(7)
+	original.SetSelfLink("/api/v1/namespaces/origin-ns/configmaps/shared-name")
+	deletionTimestamp := metav1.Now()
+	original.SetDeletionTimestamp(&deletionTimestamp)
+	gracePeriod := int64(30)
+	original.SetDeletionGracePeriodSeconds(&gracePeriod)
 	original.SetOwnerReferences([]metav1.OwnerReference{{
 		APIVersion: "cert-manager.io/v1",
 		Kind:       "Certificate",
@@ -222,6 +395,7 @@ func TestStripOriginalState(t *testing.T) {
 		"example.com/note":                 "keep me",
 	})
 	require.NoError(t, unstructured.SetNestedStringMap(original.Object, map[string]string{"key": "value"}, "data"))
+	require.NoError(t, unstructured.SetNestedField(original.Object, "observed", "status", "phase"))
 
 	replica := original.DeepCopy()
 	stripOriginalState(replica)
@@ -238,7 +412,14 @@ func TestStripOriginalState(t *testing.T) {
 	replicaCreationTimestamp := replica.GetCreationTimestamp()
 	require.True(t, replicaCreationTimestamp.IsZero())
 	require.Empty(t, replica.GetManagedFields())
+	require.NotContains(t, replica.Object["metadata"], "generation", "the replica has a generation of its own")
+	require.NotContains(t, replica.Object["metadata"], "selfLink")
+	require.NotContains(t, replica.Object["metadata"], "deletionTimestamp")
+	require.NotContains(t, replica.Object["metadata"], "deletionGracePeriodSeconds")
 	require.NotContains(t, replica.GetAnnotations(), corev1.LastAppliedConfigAnnotation)
+	// A kind without a status subresource would otherwise keep a stale copy
+	// of the original's status.
+	require.NotContains(t, replica.Object, "status")
 
 	// the desired state is what a replica is for, so it has to survive
 	require.Equal(t, "keep me", replica.GetAnnotations()["example.com/note"])
@@ -258,7 +439,7 @@ func TestMarkAsReplica(t *testing.T) {
 	syncObject := syncv1alpha1.SyncObject{
 		ObjectMeta: metav1.ObjectMeta{Name: "my-syncobject"},
 		Spec: syncv1alpha1.SyncObjectSpec{
-			Reference: syncv1alpha1.Reference{
+			Reference: &syncv1alpha1.Reference{
 				Group:     "",
 				Version:   "v1",
 				Kind:      "ConfigMap",
@@ -275,10 +456,11 @@ func TestMarkAsReplica(t *testing.T) {
 		replica.SetNamespace("target-ns")
 		return replica
 	}
+	source := client.ObjectKey{Namespace: "source-ns", Name: "source-cm"}
 
 	t.Run("marks the replica", func(t *testing.T) {
 		replica := newReplica()
-		markAsReplica(replica, syncObject)
+		markAsReplica(replica, syncObject, source)
 
 		require.Equal(t, managedByValue, replica.GetLabels()[managedByLabel])
 		require.Equal(t, map[string]string{
@@ -293,7 +475,7 @@ func TestMarkAsReplica(t *testing.T) {
 		replica.SetLabels(map[string]string{"app.kubernetes.io/managed-by": "Helm", "team": "platform"})
 		replica.SetAnnotations(map[string]string{"example.com/note": "keep me"})
 
-		markAsReplica(replica, syncObject)
+		markAsReplica(replica, syncObject, source)
 
 		labels := replica.GetLabels()
 		require.Equal(t, managedByValue, labels[managedByLabel])
@@ -303,15 +485,24 @@ func TestMarkAsReplica(t *testing.T) {
 		require.Equal(t, "keep me", replica.GetAnnotations()["example.com/note"])
 	})
 
+	// a reference with a selector names no object, so the source has to
+	// come from the object actually being replicated
+	t.Run("records the source it is given", func(t *testing.T) {
+		replica := newReplica()
+		markAsReplica(replica, syncObject, client.ObjectKey{Namespace: "source-ns", Name: "selected-cm"})
+
+		require.Equal(t, "selected-cm", replica.GetAnnotations()[sourceNameAnnotation])
+	})
+
 	// Anything time- or state-dependent in here would make every reconcile a
 	// real write, which would wake the watch and reconcile again, forever.
 	t.Run("is deterministic", func(t *testing.T) {
 		first := newReplica()
-		markAsReplica(first, syncObject)
+		markAsReplica(first, syncObject, source)
 
 		second := newReplica()
-		markAsReplica(second, syncObject)
-		markAsReplica(second, syncObject)
+		markAsReplica(second, syncObject, source)
+		markAsReplica(second, syncObject, source)
 
 		require.Equal(t, first.Object, second.Object, "marking must be repeatable and idempotent")
 	})
@@ -320,26 +511,61 @@ func TestMarkAsReplica(t *testing.T) {
 func TestReferencesToCleanUp(t *testing.T) {
 	current := syncv1alpha1.Reference{Group: "", Version: "v1", Kind: "ConfigMap", Name: "current", Namespace: "ns"}
 	previous := syncv1alpha1.Reference{Group: "", Version: "v1", Kind: "ConfigMap", Name: "previous", Namespace: "ns"}
+	legacy := syncv1alpha1.Reference{Group: "", Version: "v1", Kind: "ConfigMap", Name: "legacy", Namespace: "ns"}
 
 	tests := []struct {
-		name    string
-		applied *syncv1alpha1.Reference
-		want    []syncv1alpha1.Reference
+		name          string
+		applied       []syncv1alpha1.Reference
+		legacyApplied *syncv1alpha1.Reference
+		want          []syncv1alpha1.Reference
 	}{
-		{"nothing applied yet", nil, []syncv1alpha1.Reference{current}},
-		{"applied matches spec", &current, []syncv1alpha1.Reference{current}},
-		{"reference changed", &previous, []syncv1alpha1.Reference{current, previous}},
+		{"nothing applied yet", nil, nil, []syncv1alpha1.Reference{current}},
+		{"applied matches spec", []syncv1alpha1.Reference{current}, nil, []syncv1alpha1.Reference{current}},
+		{"reference changed", []syncv1alpha1.Reference{previous}, nil, []syncv1alpha1.Reference{current, previous}},
+		{"reference recorded by an older version", nil, &legacy, []syncv1alpha1.Reference{current, legacy}},
+		{"legacy record matching spec", nil, &current, []syncv1alpha1.Reference{current}},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			syncObject := syncv1alpha1.SyncObject{
-				Spec:   syncv1alpha1.SyncObjectSpec{Reference: current},
-				Status: syncv1alpha1.SyncObjectStatus{AppliedReference: tt.applied},
+				Spec:   syncv1alpha1.SyncObjectSpec{Reference: &current},
+				Status: syncv1alpha1.SyncObjectStatus{AppliedReferences: tt.applied, AppliedReference: tt.legacyApplied},
 			}
 			require.Equal(t, tt.want, referencesToCleanUp(syncObject))
 		})
 	}
+
+	t.Run("a reference removed from the list", func(t *testing.T) {
+		syncObject := syncv1alpha1.SyncObject{
+			Spec:   syncv1alpha1.SyncObjectSpec{References: []syncv1alpha1.Reference{current}},
+			Status: syncv1alpha1.SyncObjectStatus{AppliedReferences: []syncv1alpha1.Reference{current, previous}},
+		}
+		require.Equal(t, []syncv1alpha1.Reference{previous}, staleReferences(syncObject))
+	})
+
+	t.Run("an unchanged selector is not stale", func(t *testing.T) {
+		selecting := current
+		selecting.Name = ""
+		selecting.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"shared": "true"}}
+		applied := selecting
+		applied.Selector = selecting.Selector.DeepCopy()
+
+		syncObject := syncv1alpha1.SyncObject{
+			Spec:   syncv1alpha1.SyncObjectSpec{References: []syncv1alpha1.Reference{selecting}},
+			Status: syncv1alpha1.SyncObjectStatus{AppliedReferences: []syncv1alpha1.Reference{applied}},
+		}
+		require.Empty(t, staleReferences(syncObject), "references must be compared by value, not by pointer")
+	})
+}
+
+func TestAllReferences(t *testing.T) {
+	first := syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Name: "first", Namespace: "ns"}
+	second := syncv1alpha1.Reference{Version: "v1", Kind: "Secret", Name: "second", Namespace: "ns"}
+
+	require.Equal(t, []syncv1alpha1.Reference{first}, syncv1alpha1.SyncObjectSpec{Reference: &first}.AllReferences())
+	require.Equal(t, []syncv1alpha1.Reference{first, second}, syncv1alpha1.SyncObjectSpec{References: []syncv1alpha1.Reference{first, second}}.AllReferences())
+	require.Empty(t, syncv1alpha1.SyncObjectSpec{}.AllReferences())
 }
 
 // testRef is the reference used by the deleteReplicas tests below.
@@ -350,7 +576,7 @@ var testRef = syncv1alpha1.Reference{
 // testSyncObject owns the replicas of testRef.
 var testSyncObject = syncv1alpha1.SyncObject{
 	ObjectMeta: metav1.ObjectMeta{Name: "owner"},
-	Spec:       syncv1alpha1.SyncObjectSpec{Reference: testRef},
+	Spec:       syncv1alpha1.SyncObjectSpec{Reference: &testRef},
 }
 
 // markedConfigMap builds a ConfigMap carrying the marks replicate would
@@ -396,10 +622,10 @@ func TestGetTargetNamespacesSkipsTerminating(t *testing.T) {
 	r := &SyncObjectReconciler{Client: fakeClient}
 
 	syncObject := syncv1alpha1.SyncObject{
-		Spec: syncv1alpha1.SyncObjectSpec{Reference: testRef},
+		Spec: syncv1alpha1.SyncObjectSpec{Reference: &testRef},
 	}
 
-	targets, err := r.getTargetNamespaces(context.Background(), syncObject)
+	targets, err := r.getTargetNamespaces(context.Background(), syncObject, targetPatterns{}, *syncObject.Spec.Reference)
 	require.NoError(t, err)
 
 	require.Equal(t, []string{"alive-ns"}, targets)
@@ -421,7 +647,7 @@ func TestReplicateSkipsTerminatingNamespace(t *testing.T) {
 
 	fakeClient := fake.NewClientBuilder().
 		WithInterceptorFuncs(interceptor.Funcs{
-			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
 				return terminating
 			},
 		}).
@@ -434,21 +660,115 @@ func TestReplicateSkipsTerminatingNamespace(t *testing.T) {
 	original.SetName(testRef.Name)
 	original.SetNamespace(testRef.Namespace)
 
-	require.NoError(t, r.replicate(context.Background(), testSyncObject, original, "doomed-ns"),
+	_, err := r.replicate(context.Background(), r.Client, testSyncObject, nil, original, "doomed-ns", testRef.Name)
+	require.NoError(t, err,
 		"a namespace being deleted is not a failure to report and retry")
 }
 
-func TestNamespaceCreatedPredicate(t *testing.T) {
+func TestNamespaceCreatedOrRelabelledPredicate(t *testing.T) {
 	// the initial list of an informer arrives as creations, so this also
 	// covers the namespaces that exist when the operator starts
-	require.True(t, namespaceCreated.Create(event.CreateEvent{}),
+	require.True(t, namespaceCreatedOrRelabelled.Create(event.CreateEvent{}),
 		"a new namespace may need replicas")
 
-	require.False(t, namespaceCreated.Update(event.UpdateEvent{}),
-		"an update never makes a namespace newly eligible")
-	require.False(t, namespaceCreated.Delete(event.DeleteEvent{}),
+	namespace := func(labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: labels}}
+	}
+
+	require.True(t, namespaceCreatedOrRelabelled.Update(event.UpdateEvent{
+		ObjectOld: namespace(map[string]string{"team": "payments"}),
+		ObjectNew: namespace(map[string]string{"team": "search"}),
+	}), "a relabelled namespace may have moved in or out of a selector's scope")
+	require.False(t, namespaceCreatedOrRelabelled.Update(event.UpdateEvent{
+		ObjectOld: namespace(map[string]string{"team": "payments"}),
+		ObjectNew: namespace(map[string]string{"team": "payments"}),
+	}), "any other update never changes whether a namespace is a target")
+
+	annotated := namespace(map[string]string{"team": "payments"})
+	annotated.Annotations = map[string]string{"owner": "payments"}
+	require.False(t, namespaceCreatedOrRelabelled.Update(event.UpdateEvent{
+		ObjectOld: namespace(map[string]string{"team": "payments"}),
+		ObjectNew: annotated,
+	}), "annotations never change whether a namespace is a target")
+	require.True(t, namespaceReannotated.Update(event.UpdateEvent{
+		ObjectOld: namespace(map[string]string{"team": "payments"}),
+		ObjectNew: annotated,
+	}), "rendered templates may refer to the annotations")
+
+	relabelled := namespace(map[string]string{"team": "search"})
+	relabelled.Annotations = map[string]string{"owner": "search"}
+	require.False(t, namespaceReannotated.Update(event.UpdateEvent{
+		ObjectOld: annotated,
+		ObjectNew: relabelled,
+	}), "a relabelled namespace is left to the other watch")
+	require.False(t, namespaceReannotated.Update(event.UpdateEvent{
+		ObjectOld: annotated,
+		ObjectNew: annotated,
+	}))
+	require.False(t, namespaceReannotated.Create(event.CreateEvent{}),
+		"a new namespace is left to the other watch")
+
+	require.False(t, namespaceCreatedOrRelabelled.Delete(event.DeleteEvent{}),
 		"a deleted namespace takes its replicas with it")
-	require.False(t, namespaceCreated.Generic(event.GenericEvent{}))
+	require.False(t, namespaceCreatedOrRelabelled.Generic(event.GenericEvent{}))
+	require.False(t, namespaceReannotated.Delete(event.DeleteEvent{}))
+	require.False(t, namespaceReannotated.Generic(event.GenericEvent{}))
+}
+
+func TestRequestsForNamespace(t *testing.T) {
+	newSyncObject := func(name string, spec syncv1alpha1.SyncObjectSpec) *syncv1alpha1.SyncObject {
+		spec.Reference = &testRef
+		return &syncv1alpha1.SyncObject{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, syncv1alpha1.AddToScheme(scheme))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newSyncObject("everywhere", syncv1alpha1.SyncObjectSpec{}),
+			newSyncObject("listed", syncv1alpha1.SyncObjectSpec{TargetNamespaces: []string{"payments-ns"}}),
+			newSyncObject("elsewhere", syncv1alpha1.SyncObjectSpec{TargetNamespaces: []string{"other-ns"}}),
+			newSyncObject("glob", syncv1alpha1.SyncObjectSpec{TargetNamespaces: []string{"payments-*"}}),
+			newSyncObject("other-glob", syncv1alpha1.SyncObjectSpec{TargetNamespaces: []string{"search-*"}}),
+			newSyncObject("selected", syncv1alpha1.SyncObjectSpec{
+				TargetNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+			}),
+			newSyncObject("not-selected", syncv1alpha1.SyncObjectSpec{
+				TargetNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "search"}},
+			}),
+			newSyncObject("templates", syncv1alpha1.SyncObjectSpec{
+				TargetNamespaces: []string{"payments-*"},
+				RenderTemplates:  true,
+			}),
+			newSyncObject("other-templates", syncv1alpha1.SyncObjectSpec{
+				TargetNamespaces: []string{"search-*"},
+				RenderTemplates:  true,
+			}),
+		).
+		Build()
+
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "payments-ns",
+		Labels: map[string]string{"team": "payments"},
+	}}
+
+	var names []string
+	for _, request := range r.requestsForNamespace(context.Background(), namespace) {
+		names = append(names, request.Name)
+	}
+	require.ElementsMatch(t, []string{"everywhere", "listed", "glob", "selected", "templates"}, names)
+
+	// only the SyncObjects rendering templates read the annotations
+	names = nil
+	for _, request := range r.requestsForReannotatedNamespace(context.Background(), namespace) {
+		names = append(names, request.Name)
+	}
+	require.Equal(t, []string{"templates"}, names)
 }
 
 func TestIsReplicaOf(t *testing.T) {
@@ -502,6 +822,22 @@ func TestIsReplicaOf(t *testing.T) {
 			require.Equal(t, tt.want, isReplicaOf(obj, testSyncObject, testRef))
 		})
 	}
+
+	t.Run("a reference with a selector owns the replicas of any of its sources", func(t *testing.T) {
+		selectorRef := testRef
+		selectorRef.Name = ""
+		selectorRef.Selector = &metav1.LabelSelector{}
+
+		obj := &unstructured.Unstructured{}
+		cm := markedConfigMap("target-ns", testSyncObject.Name, syncv1alpha1.Reference{Name: "selected", Namespace: testRef.Namespace})
+		obj.SetLabels(cm.Labels)
+		obj.SetAnnotations(cm.Annotations)
+		require.True(t, isReplicaOf(obj, testSyncObject, selectorRef))
+
+		cm = markedConfigMap("target-ns", testSyncObject.Name, syncv1alpha1.Reference{Name: "selected", Namespace: "somewhere-else"})
+		obj.SetAnnotations(cm.Annotations)
+		require.False(t, isReplicaOf(obj, testSyncObject, selectorRef), "a source in another namespace is not the selector's")
+	})
 }
 
 // TestDeleteReplicasOnlyDeletesOwnReplicas covers what used to need special
@@ -530,7 +866,7 @@ func TestDeleteReplicasOnlyDeletesOwnReplicas(t *testing.T) {
 
 	r := &SyncObjectReconciler{Client: fakeClient}
 
-	require.NoError(t, r.deleteReplicas(context.Background(), testSyncObject, testRef, nil))
+	require.NoError(t, r.deleteReplicas(context.Background(), r.Client, testSyncObject, testRef, nil))
 
 	exists := func(namespace string) bool {
 		err := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: testRef.Name}, &corev1.ConfigMap{})
@@ -556,7 +892,8 @@ func TestDeleteReplicasKeepsGivenNamespaces(t *testing.T) {
 
 	r := &SyncObjectReconciler{Client: fakeClient}
 
-	require.NoError(t, r.deleteReplicas(context.Background(), testSyncObject, testRef, []string{"keep-me"}))
+	keep := func(replica, _ client.ObjectKey) bool { return replica.Namespace == "keep-me" }
+	require.NoError(t, r.deleteReplicas(context.Background(), r.Client, testSyncObject, testRef, keep))
 
 	exists := func(namespace string) bool {
 		err := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: testRef.Name}, &corev1.ConfigMap{})
@@ -567,6 +904,278 @@ func TestDeleteReplicasKeepsGivenNamespaces(t *testing.T) {
 	require.False(t, exists("drop-me"), "a replica outside the target namespaces should be deleted")
 }
 
+func TestDesiredReplicas(t *testing.T) {
+	original := func(name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetName(name)
+		return obj
+	}
+	source := func(name string) client.ObjectKey {
+		return client.ObjectKey{Namespace: testRef.Namespace, Name: name}
+	}
+	replica := func(namespace, name string) client.ObjectKey {
+		return client.ObjectKey{Namespace: namespace, Name: name}
+	}
+	targets := []string{"target-a", "target-b"}
+
+	sourceName, err := newReplicaNamer("")
+	require.NoError(t, err)
+
+	t.Run("a reference by name keeps its replicas in the target namespaces", func(t *testing.T) {
+		keep := desiredReplicas(testRef, targets, []*unstructured.Unstructured{original(testRef.Name)}, sourceName)
+		require.True(t, keep(replica("target-a", testRef.Name), source(testRef.Name)))
+		require.False(t, keep(replica("elsewhere", testRef.Name), source(testRef.Name)))
+		require.False(t, keep(replica("target-a", "another-name"), source("another-name")), "only the replicas of the named object are its own")
+		require.False(t, keep(replica("target-a", testRef.Name), client.ObjectKey{Namespace: "elsewhere", Name: testRef.Name}),
+			"an object of the same name in another namespace is not the reference's")
+	})
+
+	t.Run("a reference by name keeps its replicas while the original is missing", func(t *testing.T) {
+		keep := desiredReplicas(testRef, targets, nil, sourceName)
+		require.True(t, keep(replica("target-a", testRef.Name), source(testRef.Name)))
+	})
+
+	t.Run("a renamed replica is only kept under its current name", func(t *testing.T) {
+		prefixed, err := newReplicaNamer("shared-{{ .Name }}")
+		require.NoError(t, err)
+
+		keep := desiredReplicas(testRef, targets, nil, prefixed)
+		require.True(t, keep(replica("target-a", "shared-"+testRef.Name), source(testRef.Name)))
+		require.False(t, keep(replica("target-a", testRef.Name), source(testRef.Name)),
+			"a replica left over from before the targetName changed should be removed")
+	})
+
+	selectorRef := testRef
+	selectorRef.Name = ""
+	selectorRef.Selector = &metav1.LabelSelector{}
+
+	t.Run("a selector keeps the replicas of the objects it still matches", func(t *testing.T) {
+		keep := desiredReplicas(selectorRef, targets, []*unstructured.Unstructured{original("still-matching")}, sourceName)
+		require.True(t, keep(replica("target-b", "still-matching"), source("still-matching")))
+		require.False(t, keep(replica("target-b", "no-longer-matching"), source("no-longer-matching")))
+		require.False(t, keep(replica("elsewhere", "still-matching"), source("still-matching")))
+	})
+
+	t.Run("a selector matching nothing keeps nothing", func(t *testing.T) {
+		keep := desiredReplicas(selectorRef, targets, nil, sourceName)
+		require.False(t, keep(replica("target-a", "anything"), source("anything")))
+	})
+}
+
+// TestKeepOfKind covers two references of the same kind in one SyncObject,
+// where a selector's replicas look just like those of a reference by name:
+// cleaning up after one must not remove what the other still wants.
+func TestKeepOfKind(t *testing.T) {
+	named := testRef
+	selecting := testRef
+	selecting.Name = ""
+	selecting.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"shared": "true"}}
+	secret := testRef
+	secret.Kind = "Secret"
+	secret.Name = "other"
+
+	sourceName, err := newReplicaNamer("")
+	require.NoError(t, err)
+
+	plans := []referencePlan{
+		{ref: named, keep: desiredReplicas(named, []string{"target"}, nil, sourceName)},
+		{ref: selecting, keep: desiredReplicas(selecting, []string{"target"}, nil, sourceName)},
+		{ref: secret, keep: func(_, _ client.ObjectKey) bool { return true }},
+	}
+
+	keep := keepOfKind(plans, selecting)
+	require.True(t, keep(client.ObjectKey{Namespace: "target", Name: testRef.Name}, client.ObjectKey{Namespace: testRef.Namespace, Name: testRef.Name}),
+		"the replica of the reference by name must survive the selector's cleanup")
+	require.False(t, keep(client.ObjectKey{Namespace: "target", Name: "unwanted"}, client.ObjectKey{Namespace: testRef.Namespace, Name: "unwanted"}),
+		"the keep of a reference of another kind must not count")
+}
+
+// TestSyncReportsEachReference covers a bundle where one source is
+// missing: the others are still replicated, and the status says which one
+// failed.
+func TestSyncReportsEachReference(t *testing.T) {
+	present := syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Name: "present", Namespace: "origin-ns"}
+	missing := syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Name: "missing", Namespace: "origin-ns"}
+
+	fakeClient := fake.NewClientBuilder().
+		WithRESTMapper(testMapper()).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "origin-ns"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "target-ns"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: present.Name, Namespace: present.Namespace}},
+		).
+		Build()
+
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	syncObject := syncv1alpha1.SyncObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle"},
+		Spec:       syncv1alpha1.SyncObjectSpec{References: []syncv1alpha1.Reference{present, missing}},
+	}
+
+	results, err := r.sync(context.Background(), syncObject)
+	require.ErrorContains(t, err, "ConfigMap origin-ns/missing", "the error should say which reference failed")
+
+	require.Len(t, results, 2)
+	require.Equal(t, present, results[0].Reference)
+	require.True(t, results[0].Synced)
+	require.Empty(t, results[0].Message)
+	require.Equal(t, missing, results[1].Reference)
+	require.False(t, results[1].Synced)
+	require.Contains(t, results[1].Message, "missing")
+
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "target-ns", Name: present.Name}, &corev1.ConfigMap{}),
+		"a missing source must not stop the others from being replicated")
+}
+
+// TestSyncWritesConcurrently covers the replicas being written side by side,
+// never more at a time than MaxConcurrentWrites, and reported as if they were
+// written in turn.
+func TestSyncWritesConcurrently(t *testing.T) {
+	objects := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "origin-ns"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: testRef.Namespace}},
+	}
+	var namespaces []string
+	for i := range 8 {
+		namespace := fmt.Sprintf("target-%d", i)
+		namespaces = append(namespaces, namespace)
+		objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})
+	}
+
+	var mu sync.Mutex
+	var writing, mostWriting int
+	fakeClient := fake.NewClientBuilder().
+		WithRESTMapper(testMapper()).
+		WithObjects(objects...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+				mu.Lock()
+				writing++
+				mostWriting = max(mostWriting, writing)
+				mu.Unlock()
+				defer func() {
+					mu.Lock()
+					writing--
+					mu.Unlock()
+				}()
+				time.Sleep(20 * time.Millisecond)
+				return c.Apply(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	r := &SyncObjectReconciler{Client: fakeClient, MaxConcurrentWrites: 3}
+	results, err := r.sync(context.Background(), testSyncObject)
+	require.NoError(t, err)
+	require.Equal(t, 3, mostWriting)
+
+	require.Len(t, results, 1)
+	require.Equal(t, int32(8), results[0].SyncedReplicas)
+	var written []string
+	for _, replica := range results[0].Replicas {
+		written = append(written, replica.Namespace)
+		require.NotNil(t, replica.LastSyncTime)
+	}
+	require.Equal(t, namespaces, written, "the replicas are listed in order regardless")
+}
+
+// TestGetOriginalsWithSelector covers a selector passing over replicas:
+// another SyncObject replicating into the source namespace must not make
+// these sources fail, nor become sources themselves.
+func TestGetOriginalsWithSelector(t *testing.T) {
+	shared := map[string]string{"shared": "true"}
+
+	replicaInSourceNamespace := markedConfigMap(testRef.Namespace, "someone-else", syncv1alpha1.Reference{Name: "from-elsewhere", Namespace: "elsewhere"})
+	replicaInSourceNamespace.Labels["shared"] = "true"
+
+	fakeClient := fake.NewClientBuilder().
+		WithObjects(
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "selected-a", Namespace: testRef.Namespace, Labels: shared}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "selected-b", Namespace: testRef.Namespace, Labels: shared}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "not-labelled", Namespace: testRef.Namespace}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "elsewhere", Labels: shared}},
+			replicaInSourceNamespace,
+		).
+		Build()
+
+	ref := testRef
+	ref.Name = ""
+	ref.Selector = &metav1.LabelSelector{MatchLabels: shared}
+
+	originals, err := getOriginals(context.Background(), fakeClient, ref)
+	require.NoError(t, err)
+
+	var names []string
+	for _, original := range originals {
+		names = append(names, original.GetName())
+	}
+	require.ElementsMatch(t, []string{"selected-a", "selected-b"}, names)
+}
+
+func TestRequestsForObjectWithSelector(t *testing.T) {
+	selectorRef := testRef
+	selectorRef.Name = ""
+	selectorRef.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"shared": "true"}}
+
+	selecting := &syncv1alpha1.SyncObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "selecting"},
+		Spec:       syncv1alpha1.SyncObjectSpec{Reference: &selectorRef},
+	}
+	naming := &syncv1alpha1.SyncObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "naming"},
+		Spec:       syncv1alpha1.SyncObjectSpec{Reference: &testRef},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, syncv1alpha1.AddToScheme(scheme))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(selecting, naming).
+		WithIndex(&syncv1alpha1.SyncObject{}, referencedObjectIndexKey, indexByReference).
+		WithIndex(&syncv1alpha1.NamespacedSyncObject{}, referencedObjectIndexKey, indexByReference).
+		Build()
+
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	requested := func(obj *corev1.ConfigMap) []string {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(testRef.GroupVersionKind())
+		u.SetNamespace(obj.Namespace)
+		u.SetName(obj.Name)
+		u.SetLabels(obj.Labels)
+		u.SetAnnotations(obj.Annotations)
+
+		var names []string
+		for _, request := range r.requestsForObject(context.Background(), u) {
+			names = append(names, request.Name)
+		}
+		return names
+	}
+
+	require.ElementsMatch(t, []string{"selecting"}, requested(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "any-name", Namespace: testRef.Namespace, Labels: map[string]string{"shared": "true"},
+	}}), "a matching object in the source namespace is a source")
+
+	require.Empty(t, requested(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "any-name", Namespace: testRef.Namespace,
+	}}), "an object not matching the selector is of no interest")
+
+	require.Empty(t, requested(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "any-name", Namespace: "elsewhere", Labels: map[string]string{"shared": "true"},
+	}}), "a matching object outside the source namespace is of no interest")
+
+	require.ElementsMatch(t, []string{"selecting"}, requested(
+		markedConfigMap("target-ns", selecting.Name, syncv1alpha1.Reference{Name: "any-name", Namespace: testRef.Namespace}),
+	), "a replica routes back to the SyncObject that made it")
+
+	require.ElementsMatch(t, []string{"naming"}, requested(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: testRef.Name, Namespace: testRef.Namespace,
+	}}), "a reference by name still finds its original")
+}
+
 // TestDeleteReplicasToleratesUnknownKind covers the referenced kind being
 // removed from the cluster, e.g. its CRD was uninstalled. The API server
 // has already removed the objects of that kind, so there is nothing to
@@ -588,7 +1197,7 @@ func TestDeleteReplicasToleratesUnknownKind(t *testing.T) {
 
 	r := &SyncObjectReconciler{Client: fakeClient}
 
-	require.NoError(t, r.deleteReplicas(context.Background(), testSyncObject, testRef, nil),
+	require.NoError(t, r.deleteReplicas(context.Background(), r.Client, testSyncObject, testRef, nil),
 		"a kind that no longer exists means there is nothing left to delete")
 }
 
@@ -602,7 +1211,7 @@ func TestHandleFinalizerCompletesWhenKindIsUnknown(t *testing.T) {
 			Finalizers:        []string{finalizerName},
 			DeletionTimestamp: &deletionTimestamp,
 		},
-		Spec: syncv1alpha1.SyncObjectSpec{Reference: testRef},
+		Spec: syncv1alpha1.SyncObjectSpec{Reference: &testRef},
 	}
 
 	scheme := runtime.NewScheme()
@@ -631,6 +1240,226 @@ func TestHandleFinalizerCompletesWhenKindIsUnknown(t *testing.T) {
 		"the finalizer must be removed, otherwise the SyncObject is stuck in Terminating forever")
 }
 
+// TestHandleFinalizerWithoutServiceAccount covers a SyncObject whose
+// serviceAccountRef can't be acted as anymore: its replicas are still
+// removed, as the operator, rather than it never going away.
+func TestHandleFinalizerWithoutServiceAccount(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceAccount syncv1alpha1.ServiceAccountReference
+		exists         bool
+	}{
+		{"ServiceAccount gone", syncv1alpha1.ServiceAccountReference{Name: "replicator", Namespace: "platform"}, false},
+		{"ServiceAccount without a namespace", syncv1alpha1.ServiceAccountReference{Name: "replicator"}, false},
+		{"ServiceAccount without permissions", syncv1alpha1.ServiceAccountReference{Name: "replicator", Namespace: "platform"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deletionTimestamp := metav1.Now()
+			syncObject := &syncv1alpha1.SyncObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              testSyncObject.Name,
+					Finalizers:        []string{finalizerName},
+					DeletionTimestamp: &deletionTimestamp,
+				},
+				Spec: syncv1alpha1.SyncObjectSpec{Reference: &testRef, ServiceAccountRef: &tt.serviceAccount},
+			}
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(scheme))
+			require.NoError(t, syncv1alpha1.AddToScheme(scheme))
+
+			objects := []client.Object{syncObject, markedConfigMap("target-ns", syncObject.Name, testRef)}
+			if tt.exists {
+				objects = append(objects, &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: tt.serviceAccount.Name, Namespace: tt.serviceAccount.Namespace}})
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				Build()
+			r := &SyncObjectReconciler{
+				Client:      fakeClient,
+				impersonate: func(string) (client.Client, error) { return forbiddenClient(scheme), nil },
+			}
+
+			stop, err := r.handleFinalizer(context.Background(), syncObject)
+			require.True(t, stop)
+			err2 := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "target-ns", Name: testRef.Name}, &corev1.ConfigMap{})
+			if tt.exists {
+				require.True(t, apierrors.IsForbidden(err), "the ServiceAccount has to be allowed to, got %v", err)
+				require.Contains(t, syncObject.Finalizers, finalizerName)
+				require.NoError(t, err2, "the replica is left alone")
+				return
+			}
+			require.NoError(t, err)
+			require.NotContains(t, syncObject.Finalizers, finalizerName)
+			require.True(t, apierrors.IsNotFound(err2), "the replica should be gone, got %v", err2)
+		})
+	}
+}
+
+// TestHandleFinalizerTenantServiceAccountGone covers cleaning up after a
+// NamespacedSyncObject whose ServiceAccount is gone, whether named in its
+// serviceAccountRef or the default one: an object its tenant could only put
+// the marks on, in a namespace it can't write to, is left alone.
+func TestHandleFinalizerTenantServiceAccountGone(t *testing.T) {
+	ref := syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Name: "shared", Namespace: "team-a"}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, syncv1alpha1.AddToScheme(scheme))
+
+	tests := []struct {
+		name           string
+		serviceAccount *syncv1alpha1.ServiceAccountReference
+	}{
+		{"serviceAccountRef", &syncv1alpha1.ServiceAccountReference{Name: "replicator"}},
+		{"default ServiceAccount", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deletionTimestamp := metav1.Now()
+			syncObject := &syncv1alpha1.SyncObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "tenant",
+					Namespace:         "team-a",
+					Finalizers:        []string{finalizerName},
+					DeletionTimestamp: &deletionTimestamp,
+				},
+				Spec: syncv1alpha1.SyncObjectSpec{Reference: &ref, ServiceAccountRef: tt.serviceAccount},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRESTMapper(testMapper()).
+				WithObjects(
+					toNamespaced(*syncObject),
+					markedConfigMap("team-a-dev", "team-a/tenant", ref),
+					markedConfigMap("kube-system", "team-a/tenant", ref),
+				).
+				WithInterceptorFuncs(tenantReviews("team-a-dev")).
+				Build()
+			r := &SyncObjectReconciler{
+				Client:      fakeClient,
+				impersonate: func(string) (client.Client, error) { return forbiddenClient(scheme), nil },
+			}
+
+			var stored syncv1alpha1.NamespacedSyncObject
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(syncObject), &stored))
+			*syncObject = fromNamespaced(stored)
+			stop, err := r.handleFinalizer(context.Background(), syncObject)
+			require.NoError(t, err)
+			require.True(t, stop)
+
+			err = fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "team-a-dev", Name: ref.Name}, &corev1.ConfigMap{})
+			require.True(t, apierrors.IsNotFound(err), "the replica should be gone, got %v", err)
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "kube-system", Name: ref.Name}, &corev1.ConfigMap{}),
+				"the tenant can't write to kube-system")
+		})
+	}
+}
+
+// TestDeleteTenantReplicasWithoutServiceAccount covers a NamespacedSyncObject
+// without a serviceAccountRef, which acts as the ServiceAccount
+// "sync-operator" of its namespace: an object its tenant could only put the
+// marks on, in a namespace it can't write to, survives both the finalizer
+// and a reference being dropped, even when that ServiceAccount could
+// delete it.
+func TestDeleteTenantReplicasWithoutServiceAccount(t *testing.T) {
+	ref := syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Name: "shared", Namespace: "team-a"}
+	dropped := syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Name: "dropped", Namespace: "team-a"}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, syncv1alpha1.AddToScheme(scheme))
+
+	t.Run("finalizer", func(t *testing.T) {
+		deletionTimestamp := metav1.Now()
+		syncObject := &syncv1alpha1.SyncObject{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "tenant",
+				Namespace:         "team-a",
+				Finalizers:        []string{finalizerName},
+				DeletionTimestamp: &deletionTimestamp,
+			},
+			Spec: syncv1alpha1.SyncObjectSpec{Reference: &ref},
+		}
+
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(testMapper()).
+			WithObjects(
+				toNamespaced(*syncObject),
+				markedConfigMap("team-a-dev", "team-a/tenant", ref),
+				markedConfigMap("kube-system", "team-a/tenant", ref),
+			).
+			WithInterceptorFuncs(tenantReviews("team-a-dev")).
+			Build()
+		r := &SyncObjectReconciler{
+			Client:      fakeClient,
+			impersonate: func(string) (client.Client, error) { return fakeClient, nil },
+		}
+
+		var stored syncv1alpha1.NamespacedSyncObject
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(syncObject), &stored))
+		*syncObject = fromNamespaced(stored)
+		stop, err := r.handleFinalizer(context.Background(), syncObject)
+		require.NoError(t, err)
+		require.True(t, stop)
+
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "team-a-dev", Name: ref.Name}, &corev1.ConfigMap{})
+		require.True(t, apierrors.IsNotFound(err), "the replica should be gone, got %v", err)
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "kube-system", Name: ref.Name}, &corev1.ConfigMap{}),
+			"the tenant can't write to kube-system")
+	})
+
+	t.Run("dropped reference", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(testMapper()).
+			WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a-dev"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: ref.Namespace}},
+				markedConfigMap("team-a-dev", "team-a/tenant", dropped),
+				markedConfigMap("kube-system", "team-a/tenant", dropped),
+			).
+			WithInterceptorFuncs(tenantReviews("team-a", "team-a-dev")).
+			Build()
+		r := &SyncObjectReconciler{
+			Client:      fakeClient,
+			impersonate: func(string) (client.Client, error) { return fakeClient, nil },
+		}
+
+		syncObject := syncv1alpha1.SyncObject{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "team-a"},
+			Spec:       syncv1alpha1.SyncObjectSpec{Reference: &ref},
+			Status:     syncv1alpha1.SyncObjectStatus{AppliedReferences: []syncv1alpha1.Reference{dropped}},
+		}
+		_, err := r.sync(context.Background(), syncObject)
+		require.NoError(t, err)
+
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "team-a-dev", Name: dropped.Name}, &corev1.ConfigMap{})
+		require.True(t, apierrors.IsNotFound(err), "the replica of the dropped reference should be gone, got %v", err)
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "kube-system", Name: dropped.Name}, &corev1.ConfigMap{}),
+			"the tenant can't write to kube-system")
+	})
+}
+
+// forbiddenClient is the client of a ServiceAccount not allowed to delete
+// anything.
+func forbiddenClient(scheme *runtime.Scheme) client.Client {
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				return apierrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), errors.New("not allowed"))
+			},
+		}).
+		Build()
+}
+
 func TestDeleteReplicasPropagatesErrors(t *testing.T) {
 	const failingNamespace = "failing-ns"
 
@@ -655,8 +1484,1652 @@ func TestDeleteReplicasPropagatesErrors(t *testing.T) {
 
 	r := &SyncObjectReconciler{Client: fakeClient}
 
-	err := r.deleteReplicas(context.Background(), testSyncObject, testRef, nil)
+	err := r.deleteReplicas(context.Background(), r.Client, testSyncObject, testRef, nil)
 	require.Error(t, err, "an error from a single namespace must not be swallowed")
 	require.ErrorContains(t, err, wantErr.Error())
 	require.True(t, deletedOK, "deletion in the non-failing namespace should still have been attempted")
 }
+
+// tenantReviews answers the SubjectAccessReviews of tenantAllowed: the
+// tenant may do everything in the namespaces listed, and nothing anywhere
+// else.
+func tenantReviews(allowedNamespaces ...string) interceptor.Funcs {
+	return interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			review, ok := obj.(*authorizationv1.SubjectAccessReview)
+			if !ok {
+				return c.Create(ctx, obj, opts...)
+			}
+			review.Status.Allowed = slices.Contains(allowedNamespaces, review.Spec.ResourceAttributes.Namespace)
+			return nil
+		},
+	}
+}
+
+// testMapper maps the kinds the tests sync to their resources, as a real
+// cluster's discovery would.
+func testMapper() meta.RESTMapper {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Secret"), meta.RESTScopeNamespace)
+	mapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	mapper.Add(rbacv1.SchemeGroupVersion.WithKind("ClusterRole"), meta.RESTScopeRoot)
+	return mapper
+}
+
+// TestSyncNamespacedSyncObject covers a tenant's NamespacedSyncObject: its
+// replicas only go where the tenant may write, and carry its namespace in
+// their marks.
+func TestSyncNamespacedSyncObject(t *testing.T) {
+	ref := syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Name: "shared", Namespace: "team-a"}
+
+	fakeClient := fake.NewClientBuilder().
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a-dev"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: ref.Namespace}},
+		).
+		WithRESTMapper(testMapper()).
+		WithInterceptorFuncs(tenantReviews("team-a", "team-a-dev")).
+		Build()
+
+	var impersonated []string
+	r := &SyncObjectReconciler{
+		Client: fakeClient,
+		impersonate: func(username string) (client.Client, error) {
+			impersonated = append(impersonated, username)
+			return fakeClient, nil
+		},
+	}
+
+	syncObject := syncv1alpha1.SyncObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "team-a"},
+		Spec:       syncv1alpha1.SyncObjectSpec{Reference: &ref},
+	}
+
+	_, err := r.sync(context.Background(), syncObject)
+	require.NoError(t, err)
+	require.Equal(t, []string{"system:serviceaccount:team-a:sync-operator"}, impersonated, "never written with the operator's own permissions")
+
+	var replica corev1.ConfigMap
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "team-a-dev", Name: ref.Name}, &replica))
+	require.Equal(t, "team-a/tenant", replica.Annotations[syncObjectAnnotation])
+
+	err = fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "team-b", Name: ref.Name}, &corev1.ConfigMap{})
+	require.True(t, apierrors.IsNotFound(err), "the tenant is not allowed to write to team-b")
+}
+
+// TestImpersonatedClientsAreDropped covers the clients kept per
+// ServiceAccount: one is dropped once no SyncObject acts as it anymore.
+func TestImpersonatedClientsAreDropped(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, syncv1alpha1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	var built []string
+	r := &SyncObjectReconciler{
+		Client: fakeClient,
+		impersonate: func(username string) (client.Client, error) {
+			built = append(built, username)
+			return fakeClient, nil
+		},
+	}
+	ctx := context.Background()
+
+	tenant := func(namespace, name string) syncv1alpha1.SyncObject {
+		return syncv1alpha1.SyncObject{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+	use := func(syncObject syncv1alpha1.SyncObject) {
+		serviceAccount, _, err := serviceAccountOf(syncObject)
+		require.NoError(t, err)
+		r.trackImpersonation(syncObjectID(syncObject), serviceAccountUsername(serviceAccount))
+		_, err = r.clientFor(syncObject)
+		require.NoError(t, err)
+	}
+
+	use(tenant("team-a", "first"))
+	use(tenant("team-a", "second"))
+	use(tenant("team-b", "first"))
+	require.Equal(t, []string{
+		"system:serviceaccount:team-a:sync-operator",
+		"system:serviceaccount:team-b:sync-operator",
+	}, built, "a ServiceAccount's client is shared")
+
+	// deleted, which Reconcile only learns from not finding it anymore
+	_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "team-a", Name: "first"}})
+	require.NoError(t, err)
+	require.Len(t, r.impersonatedClients, 2, "another SyncObject still acts as team-a's ServiceAccount")
+
+	_, err = r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "team-a", Name: "second"}})
+	require.NoError(t, err)
+	require.Len(t, r.impersonatedClients, 1, "no SyncObject acts as team-a's ServiceAccount anymore")
+
+	// acting as the operator itself drops it just the same
+	cluster := tenant("", "cluster")
+	cluster.Spec.ServiceAccountRef = &syncv1alpha1.ServiceAccountReference{Namespace: "team-c", Name: "syncer"}
+	use(cluster)
+	r.trackImpersonation(syncObjectID(cluster), "")
+	require.Len(t, r.impersonatedClients, 1)
+
+	use(tenant("team-a", "third"))
+	require.Len(t, built, 4, "a ServiceAccount acted as again gets a new client")
+}
+
+func TestCheckTenantSource(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().
+		WithRESTMapper(testMapper()).
+		WithInterceptorFuncs(tenantReviews("team-a")).
+		Build()
+
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	syncObject := syncv1alpha1.SyncObject{ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: "team-a"}}
+
+	own := syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Name: "shared", Namespace: "team-a"}
+	require.NoError(t, r.checkTenantSource(context.Background(), syncObject, own))
+
+	foreign := own
+	foreign.Namespace = "team-b"
+	require.ErrorContains(t, r.checkTenantSource(context.Background(), syncObject, foreign), "own namespace")
+
+	syncObject.Namespace = "team-b"
+	require.ErrorContains(t, r.checkTenantSource(context.Background(), syncObject, foreign), "not allowed to read")
+}
+
+// TestSyncRefusesClusterScopedReference covers a reference to a kind that
+// isn't namespaced, which a tenant's own Roles must not be enough to read
+// or write.
+func TestSyncRefusesClusterScopedReference(t *testing.T) {
+	var reviews int
+	fakeClient := fake.NewClientBuilder().
+		WithRESTMapper(testMapper()).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+			&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "admin"}},
+		).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				reviews++
+				return tenantReviews("team-a").Create(ctx, c, obj, opts...)
+			},
+		}).
+		Build()
+	r := &SyncObjectReconciler{
+		Client:      fakeClient,
+		impersonate: func(string) (client.Client, error) { return fakeClient, nil },
+	}
+
+	ref := syncv1alpha1.Reference{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole", Name: "admin", Namespace: "team-a"}
+	for _, namespace := range []string{"team-a", ""} {
+		syncObject := syncv1alpha1.SyncObject{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant", Namespace: namespace},
+			Spec:       syncv1alpha1.SyncObjectSpec{Reference: &ref, TargetName: "copy"},
+		}
+		results, err := r.sync(context.Background(), syncObject)
+		require.ErrorContains(t, err, "ClusterRole is not namespaced")
+		require.False(t, results[0].Synced)
+	}
+	require.Zero(t, reviews, "refused before any access review")
+
+	var roles rbacv1.ClusterRoleList
+	require.NoError(t, fakeClient.List(context.Background(), &roles))
+	require.Len(t, roles.Items, 1, "nothing was written")
+}
+
+func TestTenantAllowedCachesAnswers(t *testing.T) {
+	var reviews int
+	fakeClient := fake.NewClientBuilder().
+		WithRESTMapper(testMapper()).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				reviews++
+				return tenantReviews("team-a").Create(ctx, c, obj, opts...)
+			},
+		}).
+		Build()
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	serviceAccount := client.ObjectKey{Namespace: "team-a", Name: tenantServiceAccount}
+	gvk := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+	for range 2 {
+		allowed, err := r.tenantAllowed(context.Background(), serviceAccount, gvk, "team-a", "", "create", "patch")
+		require.NoError(t, err)
+		require.True(t, allowed)
+	}
+	require.Equal(t, 2, reviews, "each verb is reviewed once")
+
+	for range 2 {
+		allowed, err := r.tenantAllowed(context.Background(), serviceAccount, gvk, "team-b", "", "create", "patch")
+		require.NoError(t, err)
+		require.False(t, allowed)
+	}
+	require.Equal(t, 3, reviews, "a denial is kept as well, and stops at the first verb")
+
+	key := accessKey{user: serviceAccountUsername(serviceAccount), namespace: "team-a", resource: corev1.SchemeGroupVersion.WithResource("configmaps"), verb: "create"}
+	_, ok := r.accessReviews.get(key, time.Now())
+	require.True(t, ok)
+	_, ok = r.accessReviews.get(key, time.Now().Add(accessReviewTTL))
+	require.False(t, ok, "an answer is asked again once it expired")
+}
+
+func TestIsReplicaOfNamespacedSyncObject(t *testing.T) {
+	namespaced := testSyncObject
+	namespaced.Namespace = "tenant-ns"
+
+	replica := markedConfigMap("target-ns", "owner", testRef)
+	u := &unstructured.Unstructured{}
+	u.SetLabels(replica.Labels)
+	u.SetAnnotations(replica.Annotations)
+
+	require.True(t, isReplicaOf(u, testSyncObject, testRef))
+	require.False(t, isReplicaOf(u, namespaced, testRef), "a NamespacedSyncObject of the same name must not claim the replicas of a SyncObject")
+
+	markAsReplica(u, namespaced, client.ObjectKey{Namespace: testRef.Namespace, Name: testRef.Name})
+	require.True(t, isReplicaOf(u, namespaced, testRef))
+	require.False(t, isReplicaOf(u, testSyncObject, testRef))
+}
+
+func TestServiceAccountOf(t *testing.T) {
+	tests := []struct {
+		name            string
+		namespace       string
+		ref             *syncv1alpha1.ServiceAccountReference
+		want            client.ObjectKey
+		wantImpersonate bool
+		wantErr         string
+	}{
+		{
+			name: "SyncObject without a ServiceAccount acts as the operator",
+		},
+		{
+			name:            "SyncObject with a ServiceAccount",
+			ref:             &syncv1alpha1.ServiceAccountReference{Name: "replicator", Namespace: "platform"},
+			want:            client.ObjectKey{Namespace: "platform", Name: "replicator"},
+			wantImpersonate: true,
+		},
+		{
+			name:    "SyncObject needs the namespace of its ServiceAccount",
+			ref:     &syncv1alpha1.ServiceAccountReference{Name: "replicator"},
+			wantErr: "serviceAccountRef.namespace is required",
+		},
+		{
+			name:            "NamespacedSyncObject defaults to its tenant's ServiceAccount",
+			namespace:       "team-a",
+			want:            client.ObjectKey{Namespace: "team-a", Name: tenantServiceAccount},
+			wantImpersonate: true,
+		},
+		{
+			name:            "NamespacedSyncObject defaults to its own namespace",
+			namespace:       "team-a",
+			ref:             &syncv1alpha1.ServiceAccountReference{Name: "replicator"},
+			want:            client.ObjectKey{Namespace: "team-a", Name: "replicator"},
+			wantImpersonate: true,
+		},
+		{
+			name:      "NamespacedSyncObject cannot use another namespace's ServiceAccount",
+			namespace: "team-a",
+			ref:       &syncv1alpha1.ServiceAccountReference{Name: "replicator", Namespace: "kube-system"},
+			wantErr:   "own namespace",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			syncObject := syncv1alpha1.SyncObject{
+				ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: tt.namespace},
+				Spec:       syncv1alpha1.SyncObjectSpec{ServiceAccountRef: tt.ref},
+			}
+
+			got, impersonate, err := serviceAccountOf(syncObject)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantImpersonate, impersonate)
+		})
+	}
+}
+
+// TestSyncImpersonatesServiceAccount covers a SyncObject with a
+// serviceAccountRef: its replicas are written as that ServiceAccount, and
+// what it may not do is reported as Forbidden.
+func TestSyncImpersonatesServiceAccount(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, syncv1alpha1.AddToScheme(scheme))
+
+	syncObject := testSyncObject.DeepCopy()
+	syncObject.Spec.ServiceAccountRef = &syncv1alpha1.ServiceAccountReference{Name: "replicator", Namespace: "platform"}
+	syncObject.Spec.TargetNamespaces = []string{"target-ns"}
+
+	operatorClient := fake.NewClientBuilder().
+		WithRESTMapper(testMapper()).
+		WithScheme(scheme).
+		WithObjects(syncObject).
+		WithStatusSubresource(syncObject).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+				return errors.New("the operator's own client must not write replicas")
+			},
+		}).
+		Build()
+
+	// The ServiceAccount may read the source, but not write anywhere.
+	impersonatedClient := fake.NewClientBuilder().
+		WithRESTMapper(testMapper()).
+		WithScheme(scheme).
+		WithObjects(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: testRef.Namespace}}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+				return apierrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, testRef.Name, errors.New("not allowed"))
+			},
+		}).
+		Build()
+
+	var impersonated []string
+	r := &SyncObjectReconciler{
+		Client: operatorClient,
+		impersonate: func(username string) (client.Client, error) {
+			impersonated = append(impersonated, username)
+			return impersonatedClient, nil
+		},
+	}
+
+	results, err := r.sync(context.Background(), *syncObject)
+	require.True(t, apierrors.IsForbidden(err), "the denial should survive wrapping, got %v", err)
+	require.Len(t, results, 1)
+	require.False(t, results[0].Synced)
+	require.Equal(t, []string{"system:serviceaccount:platform:replicator"}, impersonated)
+
+	// the client is reused rather than created anew on every sync
+	_, _ = r.sync(context.Background(), *syncObject)
+	require.Len(t, impersonated, 1)
+
+	require.NoError(t, r.updateStatus(context.Background(), syncObject, results, err))
+	condition := meta.FindStatusCondition(syncObject.Status.Conditions, syncv1alpha1.ConditionReady)
+	require.NotNil(t, condition)
+	require.Equal(t, "Forbidden", condition.Reason)
+}
+
+func TestNewReplicaNamer(t *testing.T) {
+	tests := []struct {
+		name       string
+		targetName string
+		want       string
+		wantErr    string
+	}{
+		{name: "empty keeps the source name", want: "app-config"},
+		{name: "fixed name", targetName: "shared-config", want: "shared-config"},
+		{name: "template over the source name", targetName: "shared-{{ .Name }}", want: "shared-app-config"},
+		{name: "template over the namespace", targetName: "{{ .Namespace.Name }}-{{ .Name }}", want: "team-a-app-config"},
+		{name: "unparsable", targetName: "{{ .Name", wantErr: "invalid targetName"},
+		{name: "unknown field", targetName: "{{ .Labels }}", wantErr: "invalid targetName"},
+		{name: "empty result", targetName: "{{ if false }}x{{ end }}", wantErr: "empty name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, err := newReplicaNamer(tt.targetName)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			got, err := name("app-config", "team-a")
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestSyncRenamesReplicas covers a targetName: replicas get the rendered
+// name, replicas under the previous name are removed, and two sources
+// that would end up with the same name are refused.
+func TestSyncRenamesReplicas(t *testing.T) {
+	shared := map[string]string{"shared": "true"}
+
+	fakeClient := fake.NewClientBuilder().
+		WithRESTMapper(testMapper()).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testRef.Namespace}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "target-ns"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-a", Namespace: testRef.Namespace, Labels: shared}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-b", Namespace: testRef.Namespace, Labels: shared}},
+		).
+		Build()
+
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	selectorRef := testRef
+	selectorRef.Name = ""
+	selectorRef.Selector = &metav1.LabelSelector{MatchLabels: shared}
+
+	syncObject := *testSyncObject.DeepCopy()
+	syncObject.Spec.Reference = &selectorRef
+	syncObject.Spec.TargetNamespaces = []string{"target-ns"}
+
+	exists := func(name string) bool {
+		return fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "target-ns", Name: name}, &corev1.ConfigMap{}) == nil
+	}
+
+	_, err := r.sync(context.Background(), syncObject)
+	require.NoError(t, err)
+	require.True(t, exists("app-a"))
+
+	syncObject.Spec.TargetName = "shared-{{ .Name }}"
+	_, err = r.sync(context.Background(), syncObject)
+	require.NoError(t, err)
+	require.True(t, exists("shared-app-a"))
+	require.True(t, exists("shared-app-b"))
+	require.False(t, exists("app-a"), "the replica under the previous name should be removed")
+
+	syncObject.Spec.TargetName = "shared"
+	_, err = r.sync(context.Background(), syncObject)
+	require.ErrorContains(t, err, "would both be named")
+}
+
+func TestRequestsForRenamedReplica(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, syncv1alpha1.AddToScheme(scheme))
+
+	syncObject := testSyncObject.DeepCopy()
+	syncObject.Spec.TargetName = "shared-{{ .Name }}"
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(syncObject).
+		WithIndex(&syncv1alpha1.SyncObject{}, referencedObjectIndexKey, indexByReference).
+		WithIndex(&syncv1alpha1.NamespacedSyncObject{}, referencedObjectIndexKey, indexByReference).
+		Build()
+
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	replica := &unstructured.Unstructured{}
+	replica.SetGroupVersionKind(testRef.GroupVersionKind())
+	replica.SetNamespace("target-ns")
+	replica.SetName("shared-" + testRef.Name)
+	markAsReplica(replica, *syncObject, client.ObjectKey{Namespace: testRef.Namespace, Name: testRef.Name})
+
+	requests := r.requestsForObject(context.Background(), replica)
+	require.Len(t, requests, 1, "drift on a renamed replica should still reach its SyncObject")
+	require.Equal(t, syncObject.Name, requests[0].Name)
+}
+
+func TestApplyPatches(t *testing.T) {
+	newReplica := func(namespace string) *unstructured.Unstructured {
+		replica := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": testRef.Name, "namespace": namespace},
+			"data":       map[string]any{"endpoint": "https://example.com", "keep": "me"},
+		}}
+		markAsReplica(replica, testSyncObject, client.ObjectKey{Namespace: testRef.Namespace, Name: testRef.Name})
+		return replica
+	}
+	raw := func(patch string) runtime.RawExtension {
+		return runtime.RawExtension{Raw: []byte(patch)}
+	}
+
+	r := &SyncObjectReconciler{Client: fake.NewClientBuilder().Build()}
+
+	tests := []struct {
+		name      string
+		namespace string
+		patches   []syncv1alpha1.Patch
+		want      map[string]any
+		wantErr   string
+	}{
+		{
+			name:      "merge patch",
+			namespace: "target-a",
+			patches:   []syncv1alpha1.Patch{{Type: syncv1alpha1.MergePatchType, Patch: raw(`{"data":{"endpoint":"https://a.example.com"}}`)}},
+			want:      map[string]any{"endpoint": "https://a.example.com", "keep": "me"},
+		},
+		{
+			name:      "JSON patch",
+			namespace: "target-a",
+			patches:   []syncv1alpha1.Patch{{Type: syncv1alpha1.JSONPatchType, Patch: raw(`[{"op":"remove","path":"/data/keep"}]`)}},
+			want:      map[string]any{"endpoint": "https://example.com"},
+		},
+		{
+			name:      "strategic merge patch of a built-in kind",
+			namespace: "target-a",
+			patches:   []syncv1alpha1.Patch{{Type: syncv1alpha1.StrategicMergePatchType, Patch: raw(`{"data":{"endpoint":null}}`)}},
+			want:      map[string]any{"keep": "me"},
+		},
+		{
+			name:      "applied in order, only in their namespaces",
+			namespace: "target-b",
+			patches: []syncv1alpha1.Patch{
+				{Type: syncv1alpha1.MergePatchType, Patch: raw(`{"data":{"endpoint":"a"}}`), Namespaces: []string{"target-a"}},
+				{Type: syncv1alpha1.MergePatchType, Patch: raw(`{"data":{"endpoint":"b"}}`), Namespaces: []string{"target-*"}},
+				{Type: syncv1alpha1.MergePatchType, Patch: raw(`{"data":{"endpoint":"c"}}`)},
+			},
+			want: map[string]any{"endpoint": "c", "keep": "me"},
+		},
+		{
+			name:      "other kinds and sources are left alone",
+			namespace: "target-a",
+			patches: []syncv1alpha1.Patch{
+				{Type: syncv1alpha1.MergePatchType, Patch: raw(`{"data":{"endpoint":"secret"}}`), Kind: "Secret"},
+				{Type: syncv1alpha1.MergePatchType, Patch: raw(`{"data":{"endpoint":"other"}}`), Name: "other-source"},
+			},
+			want: map[string]any{"endpoint": "https://example.com", "keep": "me"},
+		},
+		{
+			name:      "a failing operation",
+			namespace: "target-a",
+			patches:   []syncv1alpha1.Patch{{Type: syncv1alpha1.JSONPatchType, Patch: raw(`[{"op":"replace","path":"/data/missing","value":"x"}]`)}},
+			wantErr:   "patch 0",
+		},
+		{
+			name:      "moving the replica",
+			namespace: "target-a",
+			patches:   []syncv1alpha1.Patch{{Type: syncv1alpha1.MergePatchType, Patch: raw(`{"metadata":{"namespace":"elsewhere"}}`)}},
+			wantErr:   "must not change the name, namespace or kind",
+		},
+		{
+			name:      "an invalid namespace pattern",
+			namespace: "target-a",
+			patches: []syncv1alpha1.Patch{
+				{Type: syncv1alpha1.MergePatchType, Patch: raw(`{"data":{"endpoint":"a"}}`), Namespaces: []string{"target-["}},
+			},
+			wantErr: "patch 0: invalid namespace pattern",
+		},
+		{
+			name:      "removing the marks",
+			namespace: "target-a",
+			patches:   []syncv1alpha1.Patch{{Type: syncv1alpha1.MergePatchType, Patch: raw(`{"metadata":{"labels":null}}`)}},
+			wantErr:   "must not remove the label",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replica := newReplica(tt.namespace)
+			err := r.applyPatches(replica, compilePatches(tt.patches), testRef.Name)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, replica.Object["data"])
+		})
+	}
+}
+
+// TestSyncReportsPatchFailures covers a patch failing in one namespace: the
+// other namespaces are still written, and the status says which replica
+// failed.
+func TestSyncReportsPatchFailures(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().
+		WithRESTMapper(testMapper()).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testRef.Namespace}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "target-a"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "target-b"}},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: testRef.Namespace},
+				Data:       map[string]string{"endpoint": "https://example.com"},
+			},
+		).
+		Build()
+
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	syncObject := *testSyncObject.DeepCopy()
+	syncObject.Spec.Patches = []syncv1alpha1.Patch{
+		{Type: syncv1alpha1.MergePatchType, Patch: runtime.RawExtension{Raw: []byte(`{"data":{"endpoint":"https://a.example.com"}}`)}, Namespaces: []string{"target-a"}},
+		{Type: syncv1alpha1.JSONPatchType, Patch: runtime.RawExtension{Raw: []byte(`[{"op":"remove","path":"/data/missing"}]`)}, Namespaces: []string{"target-b"}},
+	}
+
+	results, err := r.sync(context.Background(), syncObject)
+	require.ErrorContains(t, err, "failed patching replica target-b/"+testRef.Name)
+
+	require.Len(t, results, 1)
+	require.Len(t, results[0].PatchFailures, 1)
+	require.Equal(t, "target-b", results[0].PatchFailures[0].Namespace)
+	require.Equal(t, testRef.Name, results[0].PatchFailures[0].Name)
+
+	var replica corev1.ConfigMap
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "target-a", Name: testRef.Name}, &replica))
+	require.Equal(t, "https://a.example.com", replica.Data["endpoint"])
+
+	err = fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "target-b", Name: testRef.Name}, &corev1.ConfigMap{})
+	require.True(t, apierrors.IsNotFound(err), "a replica whose patches failed must not be written")
+
+	// each replica is reported, the failed one first
+	replicas := results[0].Replicas
+	require.Len(t, replicas, 2)
+	require.Equal(t, "target-b", replicas[0].Namespace)
+	require.Equal(t, syncv1alpha1.FailedReplicaState, replicas[0].State)
+	require.Contains(t, replicas[0].Message, "failed patching replica")
+	require.Nil(t, replicas[0].LastSyncTime)
+	require.Equal(t, "target-a", replicas[1].Namespace)
+	require.Equal(t, syncv1alpha1.SyncedReplicaState, replicas[1].State)
+	require.NotNil(t, replicas[1].LastSyncTime)
+	require.Equal(t, "999", replicas[1].SourceResourceVersion)
+}
+
+func TestRenderTemplates(t *testing.T) {
+	data := templateData{
+		Name: "app-config",
+		Namespace: templateNamespace{
+			Name:        "team-a",
+			Labels:      map[string]string{"region": "eu-west-1"},
+			Annotations: map[string]string{"owner": "payments"},
+		},
+	}
+
+	replica := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":        "{{ .Name }}",
+			"namespace":   "team-a",
+			"labels":      map[string]any{"region": "{{ .Namespace.Labels.region }}"},
+			"annotations": map[string]any{"owner": "{{ .Namespace.Annotations.owner }}"},
+		},
+		"data": map[string]any{
+			"endpoint": "https://{{ .Namespace.Name }}.{{ .Namespace.Labels.region }}.example.com",
+			"plain":    "left alone",
+			"hosts":    []any{"{{ .Namespace.Name }}.example.com", int64(42)},
+			"all":      `{{ range $k, $v := .Namespace.Labels }}{{ $k }}={{ $v }};{{ end }}`,
+		},
+	}}
+
+	require.NoError(t, renderTemplates(replica, data))
+	require.Equal(t, map[string]any{
+		"endpoint": "https://team-a.eu-west-1.example.com",
+		"plain":    "left alone",
+		"hosts":    []any{"team-a.example.com", int64(42)},
+		"all":      "region=eu-west-1;",
+	}, replica.Object["data"])
+	require.Equal(t, "{{ .Name }}", replica.GetName(), "what identifies the replica is never rendered")
+	require.Equal(t, "eu-west-1", replica.GetLabels()["region"])
+	require.Equal(t, "payments", replica.GetAnnotations()["owner"])
+
+	t.Run("a missing label fails rather than rendering a placeholder", func(t *testing.T) {
+		replica := &unstructured.Unstructured{Object: map[string]any{
+			"data": map[string]any{"zone": "{{ .Namespace.Labels.zone }}"},
+		}}
+		require.ErrorContains(t, renderTemplates(replica, data), "data.zone")
+	})
+
+	t.Run("an invalid template names the field", func(t *testing.T) {
+		replica := &unstructured.Unstructured{Object: map[string]any{
+			"data": map[string]any{"broken": "{{ .Name"},
+		}}
+		require.ErrorContains(t, renderTemplates(replica, data), "invalid template in data.broken")
+	})
+}
+
+func TestReplicateRendersTemplates(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"region": "eu-west-1"}}}).
+		Build()
+
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	original := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": testRef.Name, "namespace": testRef.Namespace},
+		"data":       map[string]any{"region": "{{ .Namespace.Labels.region }}"},
+	}}
+
+	syncObject := *testSyncObject.DeepCopy()
+	syncObject.Spec.RenderTemplates = true
+
+	_, err := r.replicate(context.Background(), r.Client, syncObject, nil, original, "team-a", testRef.Name)
+	require.NoError(t, err)
+
+	var replica corev1.ConfigMap
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "team-a", Name: testRef.Name}, &replica))
+	require.Equal(t, "eu-west-1", replica.Data["region"])
+	require.Equal(t, "{{ .Namespace.Labels.region }}", original.Object["data"].(map[string]any)["region"],
+		"the original must not be rendered in place")
+}
+
+func TestSelectKeys(t *testing.T) {
+	newSecret := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]any{"name": "tls", "namespace": "origin-ns"},
+			"data":       map[string]any{"ca.crt": "Y2E=", "tls.crt": "Y3J0", "tls.key": "a2V5"},
+			"stringData": map[string]any{"note": "hello"},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		selection syncv1alpha1.KeySelection
+		want      map[string]any
+		wantErr   string
+	}{
+		{
+			name:      "include",
+			selection: syncv1alpha1.KeySelection{Include: []string{"ca.crt"}},
+			want:      map[string]any{"ca.crt": "Y2E="},
+		},
+		{
+			name:      "include by glob",
+			selection: syncv1alpha1.KeySelection{Include: []string{"*.crt"}},
+			want:      map[string]any{"ca.crt": "Y2E=", "tls.crt": "Y3J0"},
+		},
+		{
+			name:      "exclude wins over include",
+			selection: syncv1alpha1.KeySelection{Include: []string{"tls.*"}, Exclude: []string{"*.key"}},
+			want:      map[string]any{"tls.crt": "Y3J0"},
+		},
+		{
+			name:      "exclude only",
+			selection: syncv1alpha1.KeySelection{Exclude: []string{"tls.key"}},
+			want:      map[string]any{"ca.crt": "Y2E=", "tls.crt": "Y3J0"},
+		},
+		{
+			name:      "rename",
+			selection: syncv1alpha1.KeySelection{Include: []string{"ca.crt"}, Rename: map[string]string{"ca.crt": "root-ca.pem"}},
+			want:      map[string]any{"root-ca.pem": "Y2E="},
+		},
+		{
+			name:      "renaming onto another key",
+			selection: syncv1alpha1.KeySelection{Rename: map[string]string{"ca.crt": "tls.crt"}},
+			wantErr:   `would be called "tls.crt"`,
+		},
+		{
+			name:      "invalid pattern",
+			selection: syncv1alpha1.KeySelection{Include: []string{"["}},
+			wantErr:   "invalid key pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := newSecret()
+			err := selectKeys(secret, tt.selection)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, secret.Object["data"])
+		})
+	}
+
+	t.Run("applies to stringData too", func(t *testing.T) {
+		secret := newSecret()
+		require.NoError(t, selectKeys(secret, syncv1alpha1.KeySelection{Include: []string{"ca.crt"}}))
+		require.Empty(t, secret.Object["stringData"])
+	})
+
+	t.Run("objects without data are left alone", func(t *testing.T) {
+		role := &unstructured.Unstructured{Object: map[string]any{"kind": "Role", "rules": []any{}}}
+		require.NoError(t, selectKeys(role, syncv1alpha1.KeySelection{Include: []string{"ca.crt"}}))
+		require.NotContains(t, role.Object, "data")
+	})
+}
+
+func TestReplicateCopiesStatus(t *testing.T) {
+	deploymentRef := syncv1alpha1.Reference{Group: "apps", Version: "v1", Kind: "Deployment", Name: "web", Namespace: "platform"}
+	original := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": deploymentRef.Name, "namespace": deploymentRef.Namespace},
+		"spec":       map[string]any{"replicas": int64(2)},
+		"status":     map[string]any{"replicas": int64(2), "readyReplicas": int64(2)},
+	}}
+	syncObject := syncv1alpha1.SyncObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployments"},
+		Spec:       syncv1alpha1.SyncObjectSpec{Reference: &deploymentRef},
+	}
+
+	var statusWrites int
+	fakeClient := fake.NewClientBuilder().
+		WithStatusSubresource(&appsv1.Deployment{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceApply: func(ctx context.Context, c client.Client, subResourceName string, obj runtime.ApplyConfiguration, opts ...client.SubResourceApplyOption) error {
+				statusWrites++
+				return c.SubResource(subResourceName).Apply(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := &SyncObjectReconciler{Client: fakeClient}
+	replicaKey := client.ObjectKey{Namespace: "team-a", Name: deploymentRef.Name}
+
+	_, err := r.replicate(context.Background(), r.Client, syncObject, nil, original, "team-a", deploymentRef.Name)
+	require.NoError(t, err)
+
+	var replica appsv1.Deployment
+	require.NoError(t, fakeClient.Get(context.Background(), replicaKey, &replica))
+	require.Zero(t, replica.Status.ReadyReplicas, "status is dropped by default")
+	require.Zero(t, statusWrites)
+
+	syncObject.Spec.CopyStatus = true
+	_, err = r.replicate(context.Background(), r.Client, syncObject, nil, original, "team-a", deploymentRef.Name)
+	require.NoError(t, err)
+
+	require.NoError(t, fakeClient.Get(context.Background(), replicaKey, &replica))
+	require.Equal(t, int32(2), replica.Status.ReadyReplicas)
+	require.Equal(t, 1, statusWrites)
+
+	_, err = r.replicate(context.Background(), r.Client, syncObject, nil, original, "team-a", deploymentRef.Name)
+	require.NoError(t, err)
+	require.Equal(t, 1, statusWrites, "an unchanged status is not written again")
+}
+
+// TestReplicateAppliesWithFieldManager checks that replicas are written with
+// server-side apply: fields others added survive, and fields others changed
+// are taken over or reported as fieldConflicts says.
+func TestReplicateAppliesWithFieldManager(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().Build()
+	r := &SyncObjectReconciler{Client: fakeClient}
+	ctx := context.Background()
+
+	original := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": testRef.Name, "namespace": testRef.Namespace},
+		"data":       map[string]any{"key": "value"},
+	}}
+	_, err := r.replicate(ctx, r.Client, testSyncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+
+	// someone else labels the replica, and changes what the source sets
+	var replica corev1.ConfigMap
+	replicaKey := client.ObjectKey{Namespace: "target-ns", Name: testRef.Name}
+	require.NoError(t, fakeClient.Get(ctx, replicaKey, &replica))
+	replica.Labels["policy.example.com/checked"] = "true"
+	replica.Data["key"] = "edited"
+	require.NoError(t, fakeClient.Update(ctx, &replica, client.FieldOwner("kubectl-edit")))
+
+	syncObject := testSyncObject.DeepCopy()
+	syncObject.Spec.FieldConflicts = syncv1alpha1.ReportFieldConflicts
+	_, err = r.replicate(ctx, r.Client, *syncObject, nil, original, "target-ns", testRef.Name)
+	require.True(t, apierrors.IsConflict(err), "the conflict should be reported, got %v", err)
+	require.NoError(t, fakeClient.Get(ctx, replicaKey, &replica))
+	require.Equal(t, "edited", replica.Data["key"], "a reported conflict leaves the replica alone")
+
+	syncObject.Spec.FieldConflicts = syncv1alpha1.ForceFieldConflicts
+	_, err = r.replicate(ctx, r.Client, *syncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.NoError(t, fakeClient.Get(ctx, replicaKey, &replica))
+	require.Equal(t, "value", replica.Data["key"], "a forced conflict is taken over")
+	require.Equal(t, "true", replica.Labels["policy.example.com/checked"], "fields the source doesn't set survive")
+}
+
+// TestReplicateTakesOverUpdatedFields covers a replica an older version of
+// the operator wrote with plain updates: a field removed from the source
+// since is removed from the replica too.
+func TestReplicateTakesOverUpdatedFields(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithReturnManagedFields().Build()
+	r := &SyncObjectReconciler{Client: fakeClient}
+	ctx := context.Background()
+
+	replica := markedConfigMap("target-ns", testSyncObject.Name, testRef)
+	replica.Data = map[string]string{"key": "value", "removed": "value"}
+	require.NoError(t, fakeClient.Create(ctx, replica, client.FieldOwner(updateFieldManager)))
+
+	original := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": testRef.Name, "namespace": testRef.Namespace},
+		"data":       map[string]any{"key": "value"},
+	}}
+	written, err := r.replicate(ctx, r.Client, testSyncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Equal(t, replicaApplied, written)
+
+	var got corev1.ConfigMap
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(replica), &got))
+	require.Equal(t, map[string]string{"key": "value"}, got.Data)
+	for _, entry := range got.ManagedFields {
+		require.NotEqual(t, updateFieldManager, entry.Manager, "the fields are all the operator's now")
+	}
+}
+
+// TestReplicateSkipsUnchangedReplicas checks that a replica which already is
+// what would be applied costs no write, but one that was edited, or whose
+// source lost a field, still gets one.
+func TestReplicateSkipsUnchangedReplicas(t *testing.T) {
+	var applies int
+	fakeClient := fake.NewClientBuilder().
+		WithInterceptorFuncs(interceptor.Funcs{
+			Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+				applies++
+				return c.Apply(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := &SyncObjectReconciler{Client: fakeClient}
+	ctx := context.Background()
+
+	original := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": testRef.Name, "namespace": testRef.Namespace},
+		"data":       map[string]any{"key": "value", "other": "value"},
+	}}
+	replicaKey := client.ObjectKey{Namespace: "target-ns", Name: testRef.Name}
+
+	_, err := r.replicate(ctx, r.Client, testSyncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Equal(t, 1, applies)
+	var replica corev1.ConfigMap
+	require.NoError(t, fakeClient.Get(ctx, replicaKey, &replica))
+	require.NotEmpty(t, replica.Annotations[contentHashAnnotation])
+
+	written, err := r.replicate(ctx, r.Client, testSyncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Equal(t, replicaUnchanged, written)
+	require.Equal(t, 1, applies, "an unchanged replica is not written again")
+
+	replica.Data["key"] = "edited"
+	require.NoError(t, fakeClient.Update(ctx, &replica))
+	written, err = r.replicate(ctx, r.Client, testSyncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Equal(t, replicaApplied, written)
+	require.Equal(t, 2, applies, "an edited replica is restored")
+
+	unstructured.RemoveNestedField(original.Object, "data", "other")
+	_, err = r.replicate(ctx, r.Client, testSyncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Equal(t, 3, applies, "a field removed from the source is removed from the replica")
+	require.NoError(t, fakeClient.Get(ctx, replicaKey, &replica))
+	require.Equal(t, map[string]string{"key": "value"}, replica.Data)
+
+	// The generation of a source counts its own changes, which its replica
+	// doesn't share: a spec changed and changed back leaves the content
+	// the same.
+	deployment := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "web", "namespace": testRef.Namespace, "generation": int64(4)},
+		"spec":       map[string]any{"replicas": int64(2)},
+	}}
+	_, err = r.replicate(ctx, r.Client, testSyncObject, nil, deployment, "target-ns", "web")
+	require.NoError(t, err)
+	require.Equal(t, 4, applies)
+	deployment.SetGeneration(6)
+	written, err = r.replicate(ctx, r.Client, testSyncObject, nil, deployment, "target-ns", "web")
+	require.NoError(t, err)
+	require.Equal(t, replicaUnchanged, written, "a source with a generation is compared without it")
+	require.Equal(t, 4, applies)
+}
+
+// immutableInterceptor rejects changing the data of an existing ConfigMap,
+// as the API server does for an immutable one.
+func immutableInterceptor() interceptor.Funcs {
+	return interceptor.Funcs{
+		Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+			applied := obj.(interface{ GetName() string })
+			var existing corev1.ConfigMap
+			err := c.Get(ctx, client.ObjectKey{Namespace: "target-ns", Name: applied.GetName()}, &existing)
+			if err == nil {
+				return apierrors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, existing.Name, field.ErrorList{
+					field.Forbidden(field.NewPath("data"), "field is immutable when `immutable` is set"),
+				})
+			}
+			return c.Apply(ctx, obj, opts...)
+		},
+	}
+}
+
+func TestReplicateUpdateStrategy(t *testing.T) {
+	original := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": testRef.Name, "namespace": testRef.Namespace},
+		"immutable":  true,
+		"data":       map[string]any{"key": "value"},
+	}}
+	changed := original.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(changed.Object, "changed", "data", "key"))
+
+	tests := []struct {
+		name        string
+		strategy    syncv1alpha1.UpdateStrategy
+		wantWritten replicaWrite
+		wantErr     string
+	}{
+		{"Update", syncv1alpha1.UpdateUpdateStrategy, "", "set updateStrategy to RecreateOnImmutableError"},
+		{"unset", "", "", "set updateStrategy to RecreateOnImmutableError"},
+		{"RecreateOnImmutableError", syncv1alpha1.RecreateOnImmutableErrorUpdateStrategy, replicaRecreated, ""},
+		{"Recreate", syncv1alpha1.RecreateUpdateStrategy, replicaRecreated, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(immutableInterceptor()).Build()
+			recorder := events.NewFakeRecorder(10)
+			r := &SyncObjectReconciler{Client: fakeClient, Recorder: recorder}
+			ctx := context.Background()
+
+			syncObject := testSyncObject.DeepCopy()
+			syncObject.Spec.UpdateStrategy = tt.strategy
+
+			_, err := r.replicate(ctx, r.Client, *syncObject, nil, original, "target-ns", testRef.Name)
+			require.NoError(t, err)
+			require.Contains(t, <-recorder.Events, "Created")
+			require.Contains(t, <-recorder.Events, "Created", "also recorded on the replica")
+
+			written, err := r.replicate(ctx, r.Client, *syncObject, nil, changed, "target-ns", testRef.Name)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				require.Empty(t, recorder.Events)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantWritten, written)
+
+			var replica corev1.ConfigMap
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "target-ns", Name: testRef.Name}, &replica))
+			require.Equal(t, "changed", replica.Data["key"])
+			require.Contains(t, <-recorder.Events, "Recreated")
+		})
+	}
+}
+
+// staleCache holds the replicas as they were when it was filled, like a
+// cache whose watch hasn't caught up yet.
+type staleCache struct {
+	cache.Cache
+	objects map[client.ObjectKey]*unstructured.Unstructured
+}
+
+func (c *staleCache) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	cached, ok := c.objects[key]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+	cached.DeepCopyInto(obj.(*unstructured.Unstructured))
+	return nil
+}
+
+func TestReplicateRecreatesChangedContentOnly(t *testing.T) {
+	ctx := context.Background()
+	deletes := 0
+	fakeClient := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			deletes++
+			return c.Delete(ctx, obj, opts...)
+		},
+	}).Build()
+	stale := &staleCache{objects: map[client.ObjectKey]*unstructured.Unstructured{}}
+	r := &SyncObjectReconciler{Client: fakeClient, cache: stale}
+
+	syncObject := testSyncObject.DeepCopy()
+	syncObject.Spec.UpdateStrategy = syncv1alpha1.RecreateUpdateStrategy
+	original := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": testRef.Name, "namespace": testRef.Namespace},
+		"data":       map[string]any{"key": "value"},
+	}}
+	replicaKey := client.ObjectKey{Namespace: "target-ns", Name: testRef.Name}
+
+	written, err := r.replicate(ctx, r.Client, *syncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Equal(t, replicaApplied, written)
+	written, err = r.replicate(ctx, r.Client, *syncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Equal(t, replicaUnchanged, written)
+
+	// edited by hand, the content the replica was written with is the same
+	var replica corev1.ConfigMap
+	require.NoError(t, fakeClient.Get(ctx, replicaKey, &replica))
+	replica.Data["key"] = "edited"
+	require.NoError(t, fakeClient.Update(ctx, &replica))
+	written, err = r.replicate(ctx, r.Client, *syncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Equal(t, replicaApplied, written, "a replica edited by hand is updated in place")
+	require.Zero(t, deletes)
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(testRef.GroupVersionKind())
+	require.NoError(t, fakeClient.Get(ctx, replicaKey, current))
+	stale.objects[replicaKey] = current
+
+	changed := original.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(changed.Object, "changed", "data", "key"))
+	written, err = r.replicate(ctx, r.Client, *syncObject, nil, changed, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Equal(t, replicaRecreated, written)
+	require.Equal(t, 1, deletes)
+
+	// The cache still holds the replica from before it was recreated.
+	written, err = r.replicate(ctx, r.Client, *syncObject, nil, changed, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Equal(t, replicaUnchanged, written, "the recreated replica is not recreated again")
+	require.Equal(t, 1, deletes)
+}
+
+// unwatchedCache is the cache of replicas before any of them are watched,
+// which refuses reads rather than starting a watch for them.
+type unwatchedCache struct {
+	cache.Cache
+}
+
+func (c *unwatchedCache) Get(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	return &cache.ErrResourceNotCached{GVK: obj.GetObjectKind().GroupVersionKind()}
+}
+
+func (c *unwatchedCache) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	return &cache.ErrResourceNotCached{GVK: list.GetObjectKind().GroupVersionKind()}
+}
+
+// TestReadUnwatchedReplicas covers reading the replicas of a kind that
+// isn't watched, which are asked for instead.
+func TestReadUnwatchedReplicas(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := fake.NewClientBuilder().
+		WithObjects(markedConfigMap("target-ns", testSyncObject.Name, testRef)).
+		Build()
+	r := &SyncObjectReconciler{Client: fakeClient, cache: &unwatchedCache{}}
+
+	replica := &unstructured.Unstructured{}
+	replica.SetGroupVersionKind(testRef.GroupVersionKind())
+	replica.SetNamespace("target-ns")
+	replica.SetName(testRef.Name)
+	current, err := r.currentReplica(ctx, replica)
+	require.NoError(t, err)
+	require.NotNil(t, current)
+
+	left, err := r.hasReplicasIn(ctx, testSyncObject, "target-ns")
+	require.NoError(t, err)
+	require.True(t, left)
+	left, err = r.hasReplicasIn(ctx, testSyncObject, "other-ns")
+	require.NoError(t, err)
+	require.False(t, left)
+}
+
+func TestKeepRecreations(t *testing.T) {
+	other := syncv1alpha1.Reference{Version: "v1", Kind: "Secret", Name: "other", Namespace: "default"}
+	recreation := func(name string) syncv1alpha1.Recreation {
+		return syncv1alpha1.Recreation{Namespace: "target-ns", Name: name, Reason: "ImmutableField"}
+	}
+
+	var previous []syncv1alpha1.Recreation
+	for i := range maxReferenceRecreations {
+		previous = append(previous, recreation(fmt.Sprint("old-", i)))
+	}
+	statuses := []syncv1alpha1.ReferenceStatus{
+		{Reference: other, Recreations: []syncv1alpha1.Recreation{recreation("other")}},
+		{Reference: testRef, Recreations: previous},
+	}
+
+	require.Equal(t, previous, keepRecreations(nil, statuses, testRef, 2), "recreations are kept across syncs")
+
+	kept := keepRecreations([]syncv1alpha1.Recreation{recreation("new")}, statuses, testRef, 2)
+	require.Len(t, kept, maxReferenceRecreations)
+	require.Equal(t, "new", kept[0].Name, "the newest comes first")
+	require.Equal(t, previous[:maxReferenceRecreations-1], kept[1:], "and the oldest is dropped")
+}
+
+// TestUpdateStatusLimitsRecreations covers the recreations of many
+// references being limited together, rather than each on its own.
+func TestUpdateStatusLimitsRecreations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, syncv1alpha1.AddToScheme(scheme))
+
+	syncObject := testSyncObject.DeepCopy()
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(syncObject).
+		WithStatusSubresource(syncObject).
+		Build()
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	var results []syncv1alpha1.ReferenceStatus
+	for i := range 100 {
+		ref := syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Name: fmt.Sprint("source-", i), Namespace: testRef.Namespace}
+		syncObject.Spec.References = append(syncObject.Spec.References, ref)
+		result := syncv1alpha1.ReferenceStatus{Reference: ref, Synced: true}
+		for j := range maxReferenceRecreations {
+			result.Recreations = append(result.Recreations, syncv1alpha1.Recreation{Namespace: fmt.Sprint("target-", j), Name: ref.Name, Reason: "Recreate"})
+		}
+		results = append(results, result)
+	}
+	syncObject.Spec.Reference = nil
+	require.NoError(t, r.updateStatus(context.Background(), syncObject, results, nil))
+
+	total := 0
+	for _, status := range syncObject.Status.References {
+		require.NotEmpty(t, status.Recreations, "every reference gets its share")
+		total += len(status.Recreations)
+	}
+	require.LessOrEqual(t, total, maxRecreations)
+}
+
+func TestReplicateConflictPolicy(t *testing.T) {
+	original := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": testRef.Name, "namespace": testRef.Namespace},
+		"data":       map[string]any{"key": "value"},
+	}}
+	handMade := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: "target-ns"},
+		Data:       map[string]string{"local": "value"},
+	}
+
+	tests := []struct {
+		name     string
+		existing *corev1.ConfigMap
+		policy   syncv1alpha1.ConflictPolicy
+		wantErr  string
+		wantData map[string]string
+	}{
+		{"unset skips", handMade, "", "an object that is not a replica exists", map[string]string{"local": "value"}},
+		{"Skip", handMade, syncv1alpha1.SkipConflictPolicy, "an object that is not a replica exists", map[string]string{"local": "value"}},
+		{"Adopt", handMade, syncv1alpha1.AdoptConflictPolicy, "", map[string]string{"local": "value", "key": "value"}},
+		{"Overwrite", handMade, syncv1alpha1.OverwriteConflictPolicy, "", map[string]string{"key": "value"}},
+		{"replica of another SyncObject", markedConfigMap("target-ns", "other-owner", testRef), syncv1alpha1.OverwriteConflictPolicy, `a replica of the SyncObject "other-owner" exists`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithObjects(tt.existing.DeepCopy()).Build()
+			r := &SyncObjectReconciler{Client: fakeClient}
+			ctx := context.Background()
+
+			syncObject := testSyncObject.DeepCopy()
+			syncObject.Spec.ConflictPolicy = tt.policy
+
+			_, err := r.replicate(ctx, r.Client, *syncObject, nil, original, "target-ns", testRef.Name)
+			var replica corev1.ConfigMap
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(tt.existing), &replica))
+			if tt.wantErr != "" {
+				var conflictErr *conflictError
+				require.ErrorAs(t, err, &conflictErr)
+				require.ErrorContains(t, err, tt.wantErr)
+				require.Equal(t, tt.existing.Labels, replica.Labels, "a skipped object is left alone")
+				require.Equal(t, tt.existing.Annotations, replica.Annotations)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantData, replica.Data)
+			require.Equal(t, syncObjectID(*syncObject), replica.Annotations[syncObjectAnnotation], "it is a replica from now on")
+		})
+	}
+}
+
+// TestReplicateAdoptsWithoutRecreating covers adopting an object with an
+// updateStrategy that recreates replicas: it is adopted in place, keeping
+// what else it has, rather than deleted.
+func TestReplicateAdoptsWithoutRecreating(t *testing.T) {
+	original := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": testRef.Name, "namespace": testRef.Namespace},
+		"data":       map[string]any{"key": "value"},
+	}}
+	handMade := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: "target-ns"},
+		Data:       map[string]string{"local": "value"},
+	}
+
+	tests := []struct {
+		name     string
+		strategy syncv1alpha1.UpdateStrategy
+		funcs    interceptor.Funcs
+		wantErr  string
+		wantData map[string]string
+	}{
+		{"Recreate", syncv1alpha1.RecreateUpdateStrategy, interceptor.Funcs{}, "", map[string]string{"local": "value", "key": "value"}},
+		{"RecreateOnImmutableError", syncv1alpha1.RecreateOnImmutableErrorUpdateStrategy, immutableInterceptor(), "failed adopting", map[string]string{"local": "value"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deletes := 0
+			funcs := tt.funcs
+			funcs.Delete = func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				deletes++
+				return c.Delete(ctx, obj, opts...)
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(handMade.DeepCopy()).WithInterceptorFuncs(funcs).Build()
+			recorder := events.NewFakeRecorder(10)
+			r := &SyncObjectReconciler{Client: fakeClient, Recorder: recorder}
+			ctx := context.Background()
+
+			syncObject := testSyncObject.DeepCopy()
+			syncObject.Spec.ConflictPolicy = syncv1alpha1.AdoptConflictPolicy
+			syncObject.Spec.UpdateStrategy = tt.strategy
+
+			written, err := r.replicate(ctx, r.Client, *syncObject, nil, original, "target-ns", testRef.Name)
+			require.Zero(t, deletes, "an adopted object is not deleted")
+			var replica corev1.ConfigMap
+			require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(handMade), &replica))
+			require.Equal(t, tt.wantData, replica.Data)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, replicaApplied, written)
+			require.Contains(t, <-recorder.Events, "Adopted")
+		})
+	}
+}
+
+func TestSyncReportsConflicts(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().
+		WithRESTMapper(testMapper()).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testRef.Namespace}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "target-a"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "target-b"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: testRef.Namespace}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: "target-b"}},
+		).
+		Build()
+
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	results, err := r.sync(context.Background(), testSyncObject)
+	require.NoError(t, err, "a skipped conflict is reported, not a failure")
+
+	require.Len(t, results, 1)
+	require.True(t, results[0].Synced)
+	require.Equal(t, []syncv1alpha1.Conflict{{
+		Namespace: "target-b",
+		Name:      testRef.Name,
+		Message:   "an object that is not a replica exists",
+	}}, results[0].Conflicts)
+
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "target-a", Name: testRef.Name}, &corev1.ConfigMap{}))
+}
+
+// TestSyncLimitsConflicts covers the conflicts of several references being
+// limited together, rather than each on its own.
+func TestSyncLimitsConflicts(t *testing.T) {
+	other := syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Name: "other", Namespace: testRef.Namespace}
+	builder := fake.NewClientBuilder().WithRESTMapper(testMapper()).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testRef.Namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: testRef.Namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: other.Name, Namespace: testRef.Namespace}},
+	)
+	for i := range maxConflicts {
+		namespace := fmt.Sprint("target-", i)
+		builder.WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: testRef.Name, Namespace: namespace}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: other.Name, Namespace: namespace}},
+		)
+	}
+	r := &SyncObjectReconciler{Client: builder.Build()}
+
+	syncObject := testSyncObject.DeepCopy()
+	syncObject.Spec.References = []syncv1alpha1.Reference{other}
+	results, err := r.sync(context.Background(), *syncObject)
+	require.NoError(t, err)
+
+	require.Len(t, results, 2)
+	for _, result := range results {
+		require.Len(t, result.Conflicts, maxConflicts/2)
+		require.Equal(t, int32(maxConflicts), result.DesiredReplicas, "every conflict is still counted")
+	}
+}
+
+func TestLimitReplicaStatuses(t *testing.T) {
+	replicas := []syncv1alpha1.ReplicaStatus{
+		{Namespace: "a", Name: "x", State: syncv1alpha1.SyncedReplicaState},
+		{Namespace: "c", Name: "x", State: syncv1alpha1.FailedReplicaState},
+		{Namespace: "b", Name: "x", State: syncv1alpha1.SyncedReplicaState},
+		{Namespace: "b", Name: "x", State: syncv1alpha1.ConflictReplicaState},
+	}
+
+	limited, omitted := limitReplicaStatuses(slices.Clone(replicas), 10)
+	require.Zero(t, omitted)
+	require.Equal(t, []syncv1alpha1.ReplicaStatus{replicas[3], replicas[1], replicas[0], replicas[2]}, limited,
+		"the replicas that didn't sync come first")
+
+	limited, omitted = limitReplicaStatuses(slices.Clone(replicas), 3)
+	require.Equal(t, int32(1), omitted)
+	require.Equal(t, []syncv1alpha1.ReplicaStatus{replicas[3], replicas[1], replicas[0]}, limited,
+		"synced replicas are left out first")
+}
+
+func TestKeepReplicaSyncTimes(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	written := metav1.NewTime(time.Date(2026, 2, 3, 4, 5, 6, 0, time.UTC))
+	previous := []syncv1alpha1.ReferenceStatus{{
+		Reference: testRef,
+		Replicas: []syncv1alpha1.ReplicaStatus{
+			{Namespace: "unchanged", Name: testRef.Name, LastSyncTime: &earlier},
+			{Namespace: "failing", Name: testRef.Name, LastSyncTime: &earlier},
+			{Namespace: "written", Name: testRef.Name, LastSyncTime: &earlier},
+		},
+	}}
+
+	replicas := []syncv1alpha1.ReplicaStatus{
+		{Namespace: "unchanged", Name: testRef.Name, State: syncv1alpha1.SyncedReplicaState},
+		{Namespace: "failing", Name: testRef.Name, State: syncv1alpha1.FailedReplicaState},
+		{Namespace: "written", Name: testRef.Name, State: syncv1alpha1.SyncedReplicaState, LastSyncTime: &written},
+		{Namespace: "new", Name: testRef.Name, State: syncv1alpha1.SyncedReplicaState},
+		{Namespace: "never-synced", Name: testRef.Name, State: syncv1alpha1.FailedReplicaState},
+	}
+	keepReplicaSyncTimes(replicas, previous, testRef)
+
+	require.Equal(t, &earlier, replicas[0].LastSyncTime, "an unchanged replica keeps its time, so the status doesn't change")
+	require.Equal(t, &earlier, replicas[1].LastSyncTime, "a failing one keeps the time of its last sync")
+	require.Equal(t, &written, replicas[2].LastSyncTime)
+	require.NotNil(t, replicas[3].LastSyncTime, "one found synced gets a time once")
+	require.Nil(t, replicas[4].LastSyncTime)
+}
+
+func TestUpdateStatusCountsReplicas(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, syncv1alpha1.AddToScheme(scheme))
+
+	syncObject := testSyncObject.DeepCopy()
+	var statusWrites int
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(syncObject).
+		WithStatusSubresource(syncObject).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				statusWrites++
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := &SyncObjectReconciler{Client: fakeClient}
+
+	written := metav1.NewTime(time.Date(2026, 2, 3, 4, 5, 6, 0, time.UTC))
+	otherRef := syncv1alpha1.Reference{Version: "v1", Kind: "Secret", Name: "other", Namespace: "origin-ns"}
+	results := []syncv1alpha1.ReferenceStatus{
+		{
+			Reference:       testRef,
+			DesiredReplicas: 3, SyncedReplicas: 1, FailedReplicas: 1,
+			Replicas: []syncv1alpha1.ReplicaStatus{{Namespace: "a", Name: testRef.Name, State: syncv1alpha1.SyncedReplicaState, LastSyncTime: &written}},
+		},
+		{Reference: otherRef, DesiredReplicas: 2, SyncedReplicas: 2},
+	}
+
+	require.NoError(t, r.updateStatus(context.Background(), syncObject, slices.Clone(results), nil))
+	require.Equal(t, int32(5), syncObject.Status.DesiredReplicas)
+	require.Equal(t, int32(2), syncObject.Status.ReferenceCount)
+	require.Equal(t, int32(3), syncObject.Status.SyncedReplicas)
+	require.Equal(t, int32(1), syncObject.Status.FailedReplicas)
+	require.True(t, written.Equal(syncObject.Status.LastSyncTime), "got %v", syncObject.Status.LastSyncTime)
+	require.Equal(t, 1, statusWrites)
+
+	// a sync that wrote nothing leaves the status, and the time, alone
+	results[0].Replicas = []syncv1alpha1.ReplicaStatus{{Namespace: "a", Name: testRef.Name, State: syncv1alpha1.SyncedReplicaState}}
+	require.NoError(t, r.updateStatus(context.Background(), syncObject, slices.Clone(results), nil))
+	require.True(t, written.Equal(syncObject.Status.LastSyncTime), "got %v", syncObject.Status.LastSyncTime)
+	require.Equal(t, 1, statusWrites)
+}
+
+func TestEventLimiter(t *testing.T) {
+	var limiter eventLimiter
+	now := time.Now()
+
+	require.True(t, limiter.allow("a", now))
+	require.False(t, limiter.allow("a", now.Add(eventInterval-time.Second)), "the same event again is dropped")
+	require.True(t, limiter.allow("b", now), "another event is recorded")
+	require.True(t, limiter.allow("a", now.Add(eventInterval)), "the same event is recorded again after eventInterval")
+
+	for i := range maxLimitedEvents {
+		limiter.allow(fmt.Sprint(i), now)
+	}
+	require.True(t, limiter.allow("c", now.Add(2*eventInterval)))
+	require.LessOrEqual(t, len(limiter.recent), 2, "expired events are forgotten when full")
+}
+
+func TestReplicateRecordsEvents(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().Build()
+	recorder := events.NewFakeRecorder(10)
+	r := &SyncObjectReconciler{Client: fakeClient, Recorder: recorder}
+	ctx := context.Background()
+
+	original := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": testRef.Name, "namespace": testRef.Namespace},
+		"data":       map[string]any{"key": "value"},
+	}}
+
+	_, err := r.replicate(ctx, r.Client, testSyncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Equal(t, "Normal Created Created ConfigMap target-ns/shared-name from origin-ns/shared-name", <-recorder.Events)
+	require.Equal(t, "Normal Created Created ConfigMap target-ns/shared-name from origin-ns/shared-name", <-recorder.Events, "also recorded on the replica")
+
+	_, err = r.replicate(ctx, r.Client, testSyncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Empty(t, recorder.Events, "an unchanged replica records nothing")
+
+	require.NoError(t, unstructured.SetNestedField(original.Object, "changed", "data", "key"))
+	_, err = r.replicate(ctx, r.Client, testSyncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Contains(t, <-recorder.Events, "Normal Updated")
+	require.Contains(t, <-recorder.Events, "Normal Updated")
+
+	require.NoError(t, unstructured.SetNestedField(original.Object, "value", "data", "key"))
+	written, err := r.replicate(ctx, r.Client, testSyncObject, nil, original, "target-ns", testRef.Name)
+	require.NoError(t, err)
+	require.Equal(t, replicaApplied, written)
+	require.Empty(t, recorder.Events, "the same event again is not recorded within eventInterval")
+}
+
+func TestDeleteReplicasRecordsEvents(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().
+		WithObjects(markedConfigMap("target-a", testSyncObject.Name, testRef)).
+		Build()
+	recorder := events.NewFakeRecorder(10)
+	r := &SyncObjectReconciler{Client: fakeClient, Recorder: recorder}
+
+	require.NoError(t, r.deleteReplicas(context.Background(), r.Client, testSyncObject, testRef, nil))
+	require.Equal(t, "Normal Deleted Deleted ConfigMap target-a/shared-name", <-recorder.Events)
+	require.Equal(t, "Normal Deleted Deleted ConfigMap target-a/shared-name", <-recorder.Events, "also recorded on the replica")
+}
+
+func TestSyncRecordsRefusedReplica(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().
+		WithRESTMapper(testMapper()).
+		WithObjects(markedConfigMap(testRef.Namespace, "someone-else", testRef)).
+		Build()
+	recorder := events.NewFakeRecorder(10)
+	r := &SyncObjectReconciler{Client: fakeClient, Recorder: recorder}
+
+	_, err := r.sync(context.Background(), testSyncObject)
+	require.ErrorContains(t, err, "it is itself a replica")
+	require.Contains(t, <-recorder.Events, "Warning RefusedReplica refusing to replicate ConfigMap origin-ns/shared-name")
+}
+
+// watchRecorder stands in for the controller the reference watches are
+// started with, and for the caches they are started on.
+type watchRecorder struct {
+	controller.Controller
+	watches int
+	cache   removalRecorder
+	sources []*sourceCache
+}
+
+func (w *watchRecorder) Watch(source.TypedSource[reconcile.Request]) error {
+	w.watches++
+	return nil
+}
+
+func (w *watchRecorder) newCache(opts cache.Options) (cache.Cache, error) {
+	c := &sourceCache{opts: opts, stopped: make(chan struct{})}
+	w.sources = append(w.sources, c)
+	return c, nil
+}
+
+type removalRecorder struct {
+	cache.Cache
+	removed []schema.GroupVersionKind
+}
+
+func (c *removalRecorder) RemoveInformer(_ context.Context, obj client.Object) error {
+	c.removed = append(c.removed, obj.GetObjectKind().GroupVersionKind())
+	return nil
+}
+
+// sourceCache is the cache of a source watch, closing stopped once it is
+// no longer running.
+type sourceCache struct {
+	cache.Cache
+	opts    cache.Options
+	stopped chan struct{}
+}
+
+func (c *sourceCache) Start(ctx context.Context) error {
+	<-ctx.Done()
+	close(c.stopped)
+	return nil
+}
+
+func (c *sourceCache) stopsWithin(d time.Duration) bool {
+	select {
+	case <-c.stopped:
+		return true
+	case <-time.After(d):
+		return false
+	}
+}
+
+func TestWatchReferences(t *testing.T) {
+	configMaps := syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "a"}
+	otherConfigMaps := syncv1alpha1.Reference{Version: "v1", Kind: "ConfigMap", Namespace: "default", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}}
+	secrets := syncv1alpha1.Reference{Version: "v1", Kind: "Secret", Namespace: "default", Name: "c"}
+
+	recorder := &watchRecorder{}
+	r := &SyncObjectReconciler{cache: &recorder.cache, dynamicController: recorder, newCache: recorder.newCache}
+	ctx := context.Background()
+
+	r.watchReferences(ctx, "first", []syncv1alpha1.Reference{configMaps, otherConfigMaps})
+	r.watchReferences(ctx, "second", []syncv1alpha1.Reference{configMaps, secrets})
+	require.Equal(t, 5, recorder.watches, "the replicas of each kind, and the sources of each reference, are watched once")
+
+	require.Len(t, recorder.sources, 3)
+	require.Equal(t, map[string]cache.Config{"default": {}}, recorder.sources[0].opts.DefaultNamespaces)
+	require.Equal(t, "metadata.name=a", recorder.sources[0].opts.DefaultFieldSelector.String(), "a named source is watched alone")
+	require.Equal(t, "app=web", recorder.sources[1].opts.DefaultLabelSelector.String(), "the sources of a selector are watched with it")
+	require.Nil(t, recorder.sources[1].opts.DefaultFieldSelector)
+
+	r.watchReferences(ctx, "second", []syncv1alpha1.Reference{configMaps})
+	require.Equal(t, []schema.GroupVersionKind{secrets.GroupVersionKind()}, recorder.cache.removed, "a kind no longer referenced is no longer watched")
+	require.True(t, recorder.sources[2].stopsWithin(time.Second), "neither are its sources")
+
+	r.watchReferences(ctx, "first", nil)
+	require.Len(t, recorder.cache.removed, 1, "a kind still referenced by another SyncObject is still watched")
+	require.True(t, recorder.sources[1].stopsWithin(time.Second))
+	require.False(t, recorder.sources[0].stopsWithin(10*time.Millisecond), "a source still referenced by another SyncObject is still watched")
+
+	r.watchReferences(ctx, "second", nil)
+	require.Equal(t, []schema.GroupVersionKind{secrets.GroupVersionKind(), configMaps.GroupVersionKind()}, recorder.cache.removed)
+	require.True(t, recorder.sources[0].stopsWithin(time.Second))
+	require.Empty(t, r.watches)
+
+	r.watchReferences(ctx, "first", []syncv1alpha1.Reference{secrets})
+	require.Equal(t, 7, recorder.watches, "a reference referenced again is watched again")
+}
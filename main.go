@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"os"
 
@@ -11,6 +12,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -37,6 +40,11 @@ func main() {
 		probeAddr            = flag.String("health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 		enableLeaderElection = flag.Bool("leader-elect", false, "Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 		secureMetrics        = flag.Bool("metrics-secure", true, "If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
+
+		maxConcurrentReconciles = flag.Int("max-concurrent-reconciles", 1, "The number of SyncObjects synced at the same time.")
+		maxConcurrentWrites     = flag.Int("max-concurrent-writes", 10, "The number of replicas of a single SyncObject written at the same time.")
+		kubeAPIQPS              = flag.Float64("kube-api-qps", 20, "The queries per second to the Kubernetes API server, beyond which requests are throttled client-side.")
+		kubeAPIBurst            = flag.Int("kube-api-burst", 30, "The burst of queries to the Kubernetes API server allowed on top of --kube-api-qps.")
 	)
 
 	opts := zap.Options{
@@ -47,6 +55,13 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	// The shared rate limiter below would never let a request through,
+	// where client-go would take zero for its default instead.
+	if *kubeAPIQPS <= 0 || *kubeAPIBurst <= 0 {
+		setupLog.Error(errors.New("--kube-api-qps and --kube-api-burst have to be greater than zero"), "invalid flags")
+		os.Exit(1)
+	}
+
 	metricsServerOptions := server.Options{
 		BindAddress: *metricsAddr,
 	}
@@ -58,13 +73,24 @@ func main() {
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	config := ctrl.GetConfigOrDie()
+	config.QPS = float32(*kubeAPIQPS)
+	config.Burst = *kubeAPIBurst
+	// Renewing the lease mustn't wait behind a burst of replica writes.
+	leaderElectionConfig := rest.CopyConfig(config)
+	// Without one, every client built from the config gets a limiter of its
+	// own: one per kind, and one per impersonated ServiceAccount. Sharing it
+	// makes the QPS the limit of the operator as a whole.
+	config.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(config.QPS, config.Burst)
+
+	mgr, err := ctrl.NewManager(config, ctrl.Options{
 		Scheme:  scheme,
 		Metrics: metricsServerOptions,
 		// WebhookServer:          webhook.NewServer(webhook.Options{Port: 9443}),
 		HealthProbeBindAddress: *probeAddr,
 		LeaderElection:         *enableLeaderElection,
 		LeaderElectionID:       "e02338ab.sync-operator.sj14.github.io",
+		LeaderElectionConfig:   leaderElectionConfig,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -85,6 +111,12 @@ func main() {
 	if err = (&controllers.SyncObjectReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+		// register the sanitizers of your own kinds here
+		Sanitizers: controllers.DefaultSanitizers(),
+		Recorder:   mgr.GetEventRecorder("sync-operator"),
+
+		MaxConcurrentReconciles: *maxConcurrentReconciles,
+		MaxConcurrentWrites:     *maxConcurrentWrites,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SyncObject")
 		os.Exit(1)